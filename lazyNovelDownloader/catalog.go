@@ -0,0 +1,135 @@
+// catalog, sub command of lnd listing chapters without downloading
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/search"
+)
+
+const (
+	catalogCommand       = "catalog"
+	catalogInvalidPrompt = "Invalid Arguments! One of [source] and [auto] must be specified, and [auto] requires [name]. Type in `lnd catalog -h` for help."
+)
+
+type catalogChapter struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Url   string `json:"url"`
+}
+
+type catalogResult struct {
+	Url        string           `json:"url"`
+	Method     string           `json:"method,omitempty"`
+	Error      string           `json:"error,omitempty"`
+	Chapters   []catalogChapter `json:"chapters"`
+	Duplicates []catalogChapter `json:"duplicates"`
+	Moved      int              `json:"moved"`
+	Gaps       []string         `json:"gaps"`
+}
+
+func newCatalogResult(url string, r *extract.CatalogueReport, err error) (ret catalogResult) {
+	ret.Url = url
+	if err != nil {
+		ret.Error = err.Error()
+		return
+	}
+	ret.Method = r.Method
+	ret.Chapters = make([]catalogChapter, len(r.Chapters), len(r.Chapters))
+	for i, c := range r.Chapters {
+		ret.Chapters[i] = catalogChapter{Index: i + 1, Name: c.Name, Url: c.Url}
+	}
+	ret.Duplicates = make([]catalogChapter, len(r.Duplicates), len(r.Duplicates))
+	for i, c := range r.Duplicates {
+		ret.Duplicates[i] = catalogChapter{Name: c.Name, Url: c.Url}
+	}
+	ret.Moved = r.Moved
+	ret.Gaps = make([]string, len(r.Gaps), len(r.Gaps))
+	for i, gap := range r.Gaps {
+		ret.Gaps[i] = gap.String()
+	}
+	return
+}
+
+func (r *catalogResult) print(writer io.Writer) {
+	fmt.Fprintf(writer, "Catalog: %s\n", r.Url)
+	if r.Error != "" {
+		fmt.Fprintf(writer, "    Error: %s\n", r.Error)
+		return
+	}
+	fmt.Fprintf(writer, "    Method: <%s>, Chapters: %d, Duplicates Removed: %d, Reordered: %d\n", r.Method, len(r.Chapters), len(r.Duplicates), r.Moved)
+	for _, gap := range r.Gaps {
+		fmt.Fprintf(writer, "    NOTICE: %s\n", gap)
+	}
+	for _, c := range r.Chapters {
+		fmt.Fprintf(writer, "    %5d  %s  %s\n", c.Index, c.Name, c.Url)
+	}
+	for _, c := range r.Duplicates {
+		fmt.Fprintf(writer, "    (dup)  %s  %s\n", c.Name, c.Url)
+	}
+}
+
+// catalog list chapters found by `extract.Catalogue` without fetching any content.
+func catalog(args []string) {
+	flags := flag.NewFlagSet(catalogCommand, flag.ExitOnError)
+	name := flags.String("name", "", "[optional] Novel Name, compulsory when [auto] is given")
+	author := flags.String("author", "", "[optional] Novel Author, used to search and verify catalogs")
+	source := flags.String("source", "", "[optional] URL for Catalog Html File of Novel")
+	auto := flags.Bool("auto", false, "[optional] Whether to detect catalogs automatically, given the name of novel")
+	direct := flags.Bool("direct", false, "[optional] Whether to search novel sites directly instead of web search engines")
+	asJSON := flags.Bool("json", false, "[optional] Output in JSON")
+	flags.Parse(args)
+	if len(flags.Args()) > 0 || (*source == "" && !*auto) || (*source == "" && *name == "") {
+		log.Fatalf("%s", catalogInvalidPrompt)
+	}
+
+	var urls []string
+	inspect := extract.InspectCatalogue
+	if *source != "" {
+		urls = []string{*source}
+	} else {
+		var results []search.Result
+		var err error
+		if *direct {
+			results, err = search.SearchSites(os.Stderr, *name, *author, nil)
+		} else {
+			results, err = search.Search(os.Stderr, *name, *author)
+			inspect = extract.InspectCachedCatalogue // NOTICE: Pages have been fetched when verifying them.
+		}
+		if err != nil {
+			log.Fatalf("While searching for catalogs"+errorPrompt, err)
+		}
+		urls = search.Urls(results)
+	}
+
+	results := make([]catalogResult, len(urls), len(urls))
+	done := make(chan struct{})
+	for i, url := range urls {
+		go func(i int, url string) {
+			r, err := inspect(url)
+			results[i] = newCatalogResult(url, r, err)
+			done <- struct{}{}
+		}(i, url)
+	}
+	for range urls {
+		<-done
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			log.Fatalf("While encoding JSON"+errorPrompt, err)
+		}
+		return
+	}
+	for i := range results {
+		results[i].print(os.Stdout)
+	}
+}
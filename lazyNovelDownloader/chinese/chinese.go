@@ -0,0 +1,188 @@
+// chinese convert texts between Simplified and Traditional Chinese offline, by phrases and then by characters.
+package chinese
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+// Converter convert texts by a dictionary of phrases and characters.
+// Phrases are matched first, the longest one at each position, and the rest are converted character by character.
+// NOTICE: A Converter must not be changed while converting.
+type Converter struct {
+	characters map[rune]rune
+	phrases    map[string]string
+	// starts are the first characters of phrases, so that most positions are skipped without looking up phrases.
+	starts    map[rune]bool
+	maxPhrase int
+}
+
+// NewConverter return an empty Converter, which keeps texts unchanged until dictionaries are added.
+func NewConverter() *Converter {
+	return &Converter{characters: make(map[rune]rune), phrases: make(map[string]string), starts: make(map[rune]bool)}
+}
+
+// Add add a conversion, which is a character if from has only one character, otherwise a phrase.
+// Existing conversion of from is replaced.
+func (c *Converter) Add(from, to string) {
+	runes := []rune(from)
+	switch len(runes) {
+	case 0:
+	case 1:
+		if t := []rune(to); len(t) == 1 {
+			c.characters[runes[0]] = t[0]
+			break
+		}
+		fallthrough
+	default:
+		c.phrases[from] = to
+		c.starts[runes[0]] = true
+		if len(runes) > c.maxPhrase {
+			c.maxPhrase = len(runes)
+		}
+	}
+}
+
+// AddDictionary add conversions in dictionary, one per line, e.g. `头发 頭髮`.
+// The format is compatible with dictionaries of OpenCC, i.e. `头发\t頭髮 頭發`, where only the first candidate is used.
+// Empty lines and lines starting with `#` are skipped.
+// @param reverse bool whether to convert from the second column to the first, where the first conversion of a key wins.
+func (c *Converter) AddDictionary(dictionary string, reverse bool) {
+	for _, line := range strings.Split(dictionary, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		from, to := fields[0], fields[1]
+		if reverse {
+			from, to = to, from
+			if c.has(from) {
+				continue
+			}
+		}
+		c.Add(from, to)
+	}
+}
+
+// LoadDictionary add conversions in file, whose format is described in `AddDictionary`.
+func (c *Converter) LoadDictionary(filePath string, reverse bool) error {
+	data, err := utils.ReadFileString(filePath)
+	if err != nil {
+		return err
+	}
+	c.AddDictionary(data, reverse)
+	return nil
+}
+
+func (c *Converter) has(from string) bool {
+	if runes := []rune(from); len(runes) == 1 {
+		if _, ok := c.characters[runes[0]]; ok {
+			return true
+		}
+	}
+	_, ok := c.phrases[from]
+	return ok
+}
+
+// Convert convert s by forward maximum matching of phrases.
+func (c *Converter) Convert(s string) string {
+	if c == nil {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		if c.starts[runes[i]] {
+			matched, l := false, c.maxPhrase
+			if l > len(runes)-i {
+				l = len(runes) - i
+			}
+			for ; l >= 2; l-- {
+				if to, ok := c.phrases[string(runes[i:i+l])]; ok {
+					b.WriteString(to)
+					i += l
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+		}
+		if to, ok := c.characters[runes[i]]; ok {
+			b.WriteRune(to)
+		} else {
+			b.WriteRune(runes[i])
+		}
+		i++
+	}
+	return b.String()
+}
+
+var (
+	traditional, simplified         *Converter
+	traditionalOnce, simplifiedOnce sync.Once
+)
+
+// NewTraditional create a Converter from Simplified Chinese to Traditional Chinese with the built-in dictionary,
+// which can be extended by `Add` or `LoadDictionary` without affecting others.
+func NewTraditional() *Converter {
+	c := NewConverter()
+	c.AddDictionary(simplifiedPhrases, false)
+	c.AddDictionary(splitPairs(characters), false)
+	return c
+}
+
+// NewSimplified create a Converter from Traditional Chinese to Simplified Chinese with the built-in dictionary,
+// which can be extended by `Add` or `LoadDictionary` without affecting others.
+func NewSimplified() *Converter {
+	c := NewConverter()
+	c.AddDictionary(traditionalPhrases, false)
+	c.AddDictionary(splitPairs(traditionalCharacters), false)
+	c.AddDictionary(splitPairs(characters), true)
+	return c
+}
+
+// Traditional return the shared Converter created by `NewTraditional`.
+// NOTICE: It is read-only, since it is used concurrently, e.g. by `extract.ParseTitle`. Extend your own `NewTraditional()` instead.
+func Traditional() *Converter {
+	traditionalOnce.Do(func() {
+		traditional = NewTraditional()
+	})
+	return traditional
+}
+
+// Simplified return the shared Converter created by `NewSimplified`.
+// NOTICE: It is read-only, since it is used concurrently, e.g. by `extract.ParseTitle`. Extend your own `NewSimplified()` instead.
+func Simplified() *Converter {
+	simplifiedOnce.Do(func() {
+		simplified = NewSimplified()
+	})
+	return simplified
+}
+
+// ToTraditional convert s from Simplified Chinese to Traditional Chinese.
+func ToTraditional(s string) string {
+	return Traditional().Convert(s)
+}
+
+// ToSimplified convert s from Traditional Chinese to Simplified Chinese.
+func ToSimplified(s string) string {
+	return Simplified().Convert(s)
+}
+
+// splitPairs turn pairs of characters, e.g. `发發 后後`, into lines of dictionary.
+func splitPairs(pairs string) string {
+	var b strings.Builder
+	for _, pair := range strings.Fields(pairs) {
+		runes := []rune(pair)
+		if len(runes) != 2 {
+			continue
+		}
+		b.WriteString(string(runes[0]) + " " + string(runes[1]) + "\n")
+	}
+	return b.String()
+}
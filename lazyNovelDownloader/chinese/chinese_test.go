@@ -0,0 +1,69 @@
+package chinese_test
+
+import (
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/chinese"
+)
+
+func TestToTraditional(t *testing.T) {
+	type Data struct {
+		s      string
+		expect string
+	}
+	data := []Data{
+		Data{"第一百二十章 风暴之主", "第一百二十章 風暴之主"},
+		Data{"他的头发乱了，于是去理发。", "他的頭髮亂了，於是去理髮。"},
+		Data{"开头发现一只猫，这只是开始。", "開頭發現一隻貓，這只是開始。"},
+		Data{"皇后在后面，干净的干粮。", "皇后在後面，乾淨的乾糧。"},
+		Data{"除了解决问题，还要了解他。", "除了解決問題，還要瞭解他。"},
+		Data{"Chapter 7: The Fool", "Chapter 7: The Fool"},
+	}
+	for _, d := range data {
+		if ret := chinese.ToTraditional(d.s); ret != d.expect {
+			t.Errorf("Get %v from %v. Expect %v.\n", ret, d.s, d.expect)
+		}
+	}
+}
+
+func TestToSimplified(t *testing.T) {
+	type Data struct {
+		s      string
+		expect string
+	}
+	data := []Data{
+		Data{"第一百二十章 風暴之主", "第一百二十章 风暴之主"},
+		Data{"他的頭髮亂了，於是去理髮。", "他的头发乱了，于是去理发。"},
+		Data{"看著那本著名的著作，乾坤未定，衣服乾了。", "看着那本著名的著作，乾坤未定，衣服干了。"},
+		Data{"裏面有隻貓", "里面有只猫"},
+	}
+	for _, d := range data {
+		if ret := chinese.ToSimplified(d.s); ret != d.expect {
+			t.Errorf("Get %v from %v. Expect %v.\n", ret, d.s, d.expect)
+		}
+	}
+}
+
+func TestAddDictionary(t *testing.T) {
+	c := chinese.NewConverter()
+	c.AddDictionary("# comment\n克莱恩\t克萊恩 克萊因\n莫\t莫\n", false)
+	if ret := c.Convert("克莱恩·莫雷蒂"); ret != "克萊恩·莫雷蒂" {
+		t.Errorf("Get %v. Expect %v.\n", ret, "克萊恩·莫雷蒂")
+	}
+	c.AddDictionary("克莱恩 克萊恩\n", true)
+	if ret := c.Convert("克萊恩"); ret != "克莱恩" {
+		t.Errorf("Get %v. Expect %v.\n", ret, "克莱恩")
+	}
+}
+
+func TestNewTraditional(t *testing.T) {
+	c := chinese.NewTraditional()
+	c.Add("克莱恩", "克萊因")
+	if ret, expect := c.Convert("克莱恩的头发"), "克萊因的頭髮"; ret != expect {
+		t.Errorf("Get %v. Expect %v.\n", ret, expect)
+	}
+	// The shared one is not affected.
+	if ret, expect := chinese.ToTraditional("克莱恩的头发"), "克萊恩的頭髮"; ret != expect {
+		t.Errorf("Get %v. Expect %v.\n", ret, expect)
+	}
+}
@@ -0,0 +1,600 @@
+// dict hold the built-in dictionary. Phrases are in the format of `AddDictionary`, and characters are written in pairs.
+package chinese
+
+// characters are pairs of a simplified character and its most common traditional form.
+// They are also read backwards for traditional characters.
+const characters = `
+计計 订訂 认認 讥譏 讨討 让讓 训訓 议議 讯訊 记記 讲講 讳諱 讶訝 许許 论論 讼訟 设設 访訪 诀訣 证證
+评評 识識 诈詐 诉訴 词詞 译譯 试試 诗詩 诚誠 话話 诞誕 询詢 该該 详詳 语語 误誤 诱誘 说說 诵誦 请請
+诸諸 诺諾 读讀 课課 谁誰 调調 谅諒 谈談 谊誼 谋謀 谍諜 谎謊 谐諧 谓謂 谜謎 谢謝 谣謠 谦謙 谨謹 谬謬
+谭譚 谱譜 谴譴 诅詛 诊診 诡詭 诫誡 诬誣 诲誨 诽誹 谤謗 谏諫 谕諭 谙諳 谛諦 谒謁 讽諷 诏詔 誉譽 讪訕
+讫訖 讷訥 诃訶 诋詆 诌謅 诘詰 诙詼 诣詣 诤諍 诧詫 诠詮 诨諢 诮誚 谀諛 谄諂 谆諄 谘諮 谚諺 谧謐 谪謫
+谩謾 谰讕 谲譎 谵譫 诿諉 讴謳 讹訛 讧訌 讦訐 誊謄 变變 钉釘 针針 钓釣 钙鈣 钝鈍 钞鈔 钟鐘 钢鋼 钥鑰
+钦欽 钩鉤 钱錢 钳鉗 钻鑽 铁鐵 铃鈴 铅鉛 铜銅 铝鋁 铭銘 银銀 铺鋪 链鏈 销銷 锁鎖 锅鍋 锈鏽 锋鋒 锐銳
+错錯 锡錫 锣鑼 锤錘 锦錦 键鍵 锯鋸 锻鍛 镇鎮 镜鏡 镖鏢 镰鐮 镯鐲 镶鑲 钧鈞 钮鈕 铸鑄 铲鏟 铠鎧 铛鐺
+铮錚 铿鏗 锄鋤 锥錐 锭錠 锚錨 锰錳 锹鍬 锵鏘 镀鍍 镁鎂 镂鏤 镐鎬 镑鎊 镣鐐 镭鐳 钠鈉 钾鉀 钴鈷 铀鈾
+铂鉑 铬鉻 锌鋅 钛鈦 钨鎢 铆鉚 铐銬 铣銑 铰鉸 铡鍘 钵缽 钏釧 钗釵 铎鐸 铙鐃 锏鐧 镊鑷 锢錮 锲鍥 镌鐫
+鉴鑒 铄鑠 钺鉞 锷鍔 镝鏑 钰鈺 铉鉉 锟錕 铢銖 锱錙 饥飢 饭飯 饮飲 饰飾 饱飽 饲飼 饶饒 饺餃 饼餅 饵餌
+饿餓 馁餒 馅餡 馆館 馈饋 馋饞 馍饃 馒饅 饯餞 饪飪 饴飴 饷餉 馄餛 馊餿 纠糾 红紅 纤纖 约約 级級 纪紀
+纯純 纱紗 纲綱 纳納 纵縱 纷紛 纸紙 纹紋 纺紡 线線 练練 组組 绅紳 细細 织織 终終 绊絆 绍紹 经經 绑綁
+绒絨 结結 绕繞 绘繪 给給 络絡 绝絕 绞絞 统統 绢絹 绣繡 继繼 绩績 绪緒 续續 绳繩 维維 绵綿 绷繃 绸綢
+综綜 绽綻 绿綠 缀綴 缄緘 缅緬 缆纜 缎緞 缓緩 缔締 缕縷 编編 缘緣 缚縛 缝縫 缠纏 缤繽 缩縮 缭繚 缰韁
+缴繳 绰綽 绫綾 绮綺 绯緋 绻綣 缈緲 缥縹 缨纓 缪繆 纬緯 纫紉 纶綸 纽紐 绌絀 绎繹 绚絢 绦絛 绥綏 缉緝
+缇緹 缮繕 缢縊 缙縉 缜縝 缱繾 绛絳 门門 闪閃 闭閉 问問 闯闖 闲閒 间間 闷悶 闸閘 闹鬧 闺閨 闻聞 阀閥
+阁閣 阅閱 阔闊 阐闡 阎閻 阙闕 闰閏 闽閩 阂閡 阖闔 阕闋 阑闌 阉閹 阄鬮 闾閭 闱闈 阈閾 阊閶 贝貝 贞貞
+负負 贡貢 财財 责責 贤賢 败敗 账賬 货貨 质質 贩販 贪貪 贫貧 购購 贯貫 贱賤 贴貼 贵貴 贷貸 贸貿 费費
+贺賀 贼賊 贿賄 资資 赋賦 赌賭 赏賞 赐賜 赔賠 赖賴 赚賺 赛賽 赞讚 赠贈 赡贍 赢贏 赂賂 赃贓 赁賃 赊賒
+赈賑 赘贅 赎贖 赓賡 贬貶 贮貯 贻貽 贾賈 赅賅 赉賚 页頁 顶頂 项項 顺順 须須 顽頑 顾顧 顿頓 颁頒 预預
+领領 颇頗 频頻 颗顆 题題 颜顏 额額 颠顛 颤顫 颂頌 颈頸 颊頰 颓頹 颖穎 颅顱 颌頜 颐頤 颔頷 颦顰 颀頎
+马馬 驮馱 驯馴 驰馳 驱驅 驳駁 驴驢 驶駛 驻駐 驼駝 驾駕 骂罵 骄驕 骆駱 骇駭 验驗 骏駿 骑騎 骗騙 骚騷
+骤驟 驹駒 驿驛 骡騾 骥驥 骁驍 骈駢 骋騁 骐騏 骛騖 骞騫 骠驃 骢驄 鸟鳥 鸡雞 鸣鳴 鸦鴉 鸭鴨 鸳鴛 鸯鴦
+鸽鴿 鹅鵝 鹊鵲 鹏鵬 鹤鶴 鹦鸚 鹉鵡 鹰鷹 鸿鴻 鸠鳩 鹃鵑 鹂鸝 鹭鷺 鹫鷲 鸥鷗 鸾鸞 凤鳳 鸵鴕 鹌鵪 鹑鶉
+鸢鳶 鹞鷂 鹄鵠 鱼魚 鲁魯 鲜鮮 鲤鯉 鲨鯊 鲸鯨 鳄鱷 鳞鱗 鲍鮑 鲫鯽 鳌鰲 鲛鮫 鲲鯤 鳖鱉 鳗鰻 鲈鱸 鳍鰭
+鳅鰍 鲑鮭 车車 轧軋 轨軌 军軍 轩軒 转轉 轮輪 软軟 轰轟 轴軸 轻輕 载載 轿轎 较較 辅輔 辆輛 辈輩 辉輝
+辐輻 输輸 辑輯 辕轅 辖轄 辗輾 辙轍 轶軼 轼軾 轲軻 辄輒 辍輟 辇輦 辘轆 舆輿 阵陣 库庫 连連 莲蓮 裤褲
+斩斬 惭慚 渐漸 暂暫 堑塹 见見 观觀 规規 觅覓 视視 览覽 觉覺 亲親 舰艦 现現 宽寬 砚硯 觊覬 觎覦 觐覲
+觑覷 觇覘 觞觴 触觸 韦韋 韧韌 韩韓 韬韜 伟偉 围圍 违違 苇葦 炜煒 龙龍 垄壟 拢攏 笼籠 聋聾 袭襲 庞龐
+宠寵 泷瀧 珑瓏 胧朧 咙嚨 东東 冻凍 栋棟 陈陳 炼煉 拣揀 乐樂 砾礫 烁爍 长長 张張 帐帳 胀脹 涨漲 书書
+为為 办辦 务務 动動 劳勞 势勢 劝勸 区區 医醫 华華 单單 卖賣 卫衛 压壓 厅廳 历歷 厉厲 县縣 参參 双雙
+发發 叙敘 叠疊 号號 叹嘆 吓嚇 吗嗎 吕呂 启啟 员員 呜嗚 响響 哑啞 哗嘩 唤喚 啸嘯 喷噴 嘱囑 园園 团團
+国國 图圖 圆圓 圣聖 场場 坏壞 块塊 坚堅 坛壇 坝壩 坞塢 坟墳 坠墜 垒壘 垫墊 堕墮 墙牆 壮壯 声聲 处處
+备備 复復 够夠 头頭 夹夾 夺奪 奋奮 奖獎 妆妝 妇婦 妈媽 娄婁 娇嬌 娱娛 婴嬰 孙孫 学學 宁寧 宝寶 实實
+宪憲 审審 宾賓 寝寢 对對 寻尋 导導 寿壽 将將 尔爾 尘塵 尝嘗 层層 属屬 岁歲 岂豈 岗崗 岛島 岭嶺 峡峽
+币幣 师師 帅帥 带帶 帮幫 干幹 并並 广廣 庄莊 庆慶 庐廬 应應 庙廟 废廢 开開 异異 弃棄 弯彎 弹彈 强強
+归歸 当當 录錄 彦彥 彻徹 径徑 忆憶 忧憂 怀懷 态態 怜憐 总總 恋戀 恶惡 恳懇 恼惱 悦悅 悬懸 惊驚 惧懼
+惨慘 惩懲 惫憊 愤憤 愿願 慑懾 懒懶 戏戲 战戰 户戶 扑撲 执執 扩擴 扫掃 扬揚 扰擾 抚撫 抛拋 抢搶 护護
+报報 担擔 拟擬 拥擁 拦攔 择擇 挂掛 挡擋 挣掙 挤擠 挥揮 捞撈 损損 捡撿 换換 掷擲 揽攬 搀攙 摄攝 摆擺
+摇搖 摊攤 撑撐 敌敵 数數 斋齋 断斷 无無 旧舊 时時 旷曠 昼晝 显顯 晋晉 晒曬 晓曉 晕暈 术術 机機 杀殺
+杂雜 权權 条條 来來 杨楊 极極 构構 枪槍 柜櫃 标標 栏欄 树樹 样樣 桥橋 梦夢 检檢 楼樓 欢歡 欧歐 残殘
+殴毆 毁毀 毕畢 毙斃 气氣 汇匯 汉漢 汤湯 沟溝 没沒 沪滬 泪淚 泼潑 泽澤 洁潔 浅淺 浆漿 浇澆 浊濁 测測
+济濟 浏瀏 浓濃 涂塗 润潤 涛濤 涡渦 淀澱 渊淵 温溫 湾灣 湿濕 满滿 滚滾 滞滯 滤濾 滥濫 滨濱 滩灘 潜潛
+灭滅 灯燈 灵靈 灾災 灿燦 炉爐 点點 烂爛 烛燭 烟煙 烦煩 烧燒 热熱 焕煥 爱愛 爷爺 牵牽 犹猶 狈狽 独獨
+狭狹 狮獅 猎獵 猪豬 猫貓 献獻 环環 玛瑪 琐瑣 电電 画畫 畅暢 疗療 疮瘡 疯瘋 痒癢 痴癡 瘾癮 盏盞 监監
+盘盤 盖蓋 盗盜 睁睜 矫矯 码碼 础礎 硕碩 确確 碍礙 礼禮 祸禍 离離 秃禿 种種 称稱 积積 稳穩 穷窮 窃竊
+窍竅 窝窩 竞競 笔筆 笋筍 笺箋 筑築 筹籌 简簡 粮糧 紧緊 罚罰 罗羅 罢罷 网網 羡羨 习習 翘翹 耸聳 职職
+联聯 肃肅 肠腸 肤膚 肾腎 肿腫 胁脅 胆膽 胜勝 脉脈 脏臟 脑腦 脚腳 脱脫 腊臘 腻膩 腾騰 舱艙 艺藝 节節
+芦蘆 苍蒼 苏蘇 荐薦 荡蕩 荣榮 药藥 获獲 莹瑩 萝蘿 营營 萧蕭 蓝藍 虏虜 虑慮 虚虛 虫蟲 虽雖 虾蝦 蚀蝕
+蚁蟻 蛮蠻 蜡蠟 补補 衬襯 袄襖 装裝 赵趙 赶趕 跃躍 践踐 踪蹤 蹿躥 躯軀 边邊 辽遼 达達 迁遷 过過 迈邁
+运運 还還 这這 进進 远遠 迟遲 适適 选選 递遞 逻邏 遗遺 邓鄧 邮郵 邻鄰 郑鄭 酝醞 酱醬 酿釀 释釋 里裡
+队隊 阳陽 阴陰 际際 陆陸 陕陝 险險 随隨 隐隱 难難 雾霧 韵韻 风風 飘飄 飞飛 髅髏 鬓鬢 麦麥 齐齊 齿齒
+龄齡 龟龜 丝絲 丢丟 两兩 严嚴 丧喪 个個 丰豐 临臨 丽麗 举舉 乌烏 乔喬 乡鄉 买買 乱亂 争爭 亏虧 亚亞
+产產 亩畝 亿億 仅僅 从從 仑侖 仓倉 仪儀 们們 价價 众眾 优優 伙夥 会會 伞傘 伤傷 伦倫 伪偽 体體 佣傭
+侠俠 侣侶 侥僥 侦偵 侧側 侨僑 侬儂 俭儉 债債 倾傾 偿償 储儲 儿兒 兑兌 党黨 兰蘭 关關 兴興 养養 兽獸
+册冊 写寫 农農 冯馮 冲衝 决決 况況 净淨 凉涼 减減 凑湊 几幾 凭憑 凯凱 击擊 凿鑿 刍芻 划劃 刘劉 则則
+刚剛 创創 删刪 别別 剂劑 剑劍 剥剝 剧劇 励勵 劲勁 勋勳 匀勻 协協 卢盧 卤滷 卧臥 却卻 厂廠 厌厭 厕廁
+厘釐 厢廂 厦廈 叶葉 后後 吨噸 听聽 吴吳 呕嘔 呛嗆 咏詠 哟喲 唠嘮 啧嘖 喽嘍 嘘噓 嘤嚶 噜嚕 垦墾 壳殼
+壶壺 夸誇 妩嫵 娆嬈 娴嫻 婶嬸 嫔嬪 嬷嬤 孪孿 尧堯 尴尷 尸屍 尽盡 屉屜 届屆 屡屢 屿嶼 岖嶇 岚嵐 峥崢
+峦巒 崭嶄 嵘嶸 巅巔 巩鞏 帘簾 帧幀 帼幗 幂冪 弥彌 忏懺 怂慫 怅悵 怆愴 恸慟 恺愷 恻惻 悭慳 悯憫 惬愜
+惮憚 惯慣 愠慍 懑懣 抠摳 抡掄 拧擰 拨撥 挚摯 挛攣 挞撻 挟挾 挠撓 捣搗 据據 掳擄 掴摑 掸撣 掺摻 掼摜
+搁擱 搂摟 搅攪 携攜 摈擯 撵攆 撷擷 撸擼 撺攛 擞擻 攒攢 敛斂 斓斕 斗鬥 昙曇 晔曄 晖暉 暧曖 朴樸 杠槓
+杰傑 枢樞 枣棗 枫楓 枭梟 柠檸 栅柵 栈棧 栉櫛 栖棲 栾欒 桠椏 桡橈 桢楨 档檔 桦樺 桧檜 桨槳 桩樁 棂欞
+椁槨 椟櫝 椭橢 榄欖 榇櫬 榈櫚 榉櫸 槛檻 槟檳 横橫 樯檣 樱櫻 橱櫥 橹櫓 橼櫞 檩檁 欤歟 歼殲 殁歿 殇殤
+殒殞 殓殮 殚殫 殡殯 毂轂 毡氈 氢氫 氩氬 汹洶 沣灃 沤漚 沥瀝 沦淪 沧滄 泞濘 泸瀘 泻瀉 泾涇 洒灑 洼窪
+浃浹 浑渾 浒滸 浔潯 涝澇 涞淶 涟漣 涣渙 涤滌 涧澗 涩澀 渍漬 渎瀆 渔漁 渗滲 溃潰 溅濺 滟灩 滢瀅 滦灤
+潆瀠 潇瀟 潋瀲 潍濰 澜瀾 濑瀨 濒瀕 灶竈 炀煬 炖燉 炽熾 烃烴 烨燁 烩燴 烫燙 烬燼 焖燜 焘燾 牍牘 牺犧
+犊犢 状狀 犷獷 狞獰 狰猙 狱獄 狲猻 猕獼 猬蝟 獭獺 玑璣 玮瑋 玺璽 珐琺 珰璫 珲琿 琏璉 琼瓊 瑶瑤 瑷璦
+璎瓔 瓒瓚 瓯甌 畴疇 疖癤 疟瘧 疡瘍 疱皰 痈癰 痉痙 痨癆 痪瘓 痫癇 瘪癟 瘫癱 癞癩 癣癬 癫癲 皑皚 皱皺
+皲皸 盐鹽 眦眥 眬矓 着著 睐睞 睑瞼 瞒瞞 瞩矚 矶磯 矾礬 矿礦 砖磚 砺礪 碛磧 碱鹼 祢禰 祯禎 祷禱 禀稟
+禄祿 禅禪 秆稈 秽穢 稣穌 穑穡 窑窯 窜竄 窥窺 窦竇 竖豎 笃篤 筚篳 筛篩 筝箏 签簽 箧篋 箩籮 箪簞 箫簫
+篓簍 篮籃 篱籬 籁籟 类類 籼秈 粜糶 粤粵 粪糞 絷縶 罂罌 罴羆 羁羈 耻恥 聂聶 聩聵 聪聰 肮骯 胪臚 胫脛
+胶膠 脍膾 脐臍 脓膿 脔臠 脸臉 腌醃 腭齶 腼靦 膑臏 舻艫 艰艱 艳豔 芈羋 芗薌 芜蕪 苋莧 苌萇 苹蘋 茎莖
+茏蘢 茔塋 茕煢 茧繭 荆荊 荚莢 荜蓽 荞蕎 荟薈 荠薺 荤葷 荥滎 荦犖 荧熒 荨蕁 荩藎 荪蓀 荫蔭 莅蒞 莱萊
+莳蒔 莴萵 莺鶯 莼蒓 萤螢 萦縈 萨薩 葱蔥 蒋蔣 蒌蔞 蓟薊 蓦驀 蔷薔 蔺藺 蔼藹 蕲蘄 蕴蘊 薮藪 藓蘚 虬虯
+虮蟣 虿蠆 蚂螞 蚕蠶 蚝蠔 蚬蜆 蛊蠱 蛎蠣 蛏蟶 蛰蟄 蛱蛺 蛲蟯 蛳螄 蛴蠐 蜗蝸 蝇蠅 蝈蟈 蝉蟬 蝎蠍 蝼螻
+蝾蠑 螨蟎 衅釁 衔銜 衮袞 袅裊 袜襪 裆襠 裢褳 褛褸 褴襤 于於 么麼 台臺 云雲 郁鬱 咸鹹 丑醜 余餘 占佔
+准準
+`
+
+// traditionalCharacters are pairs of a traditional character and its simplified form, for traditional
+// characters which are not the most common form of any simplified one, e.g. `髮`, `乾`, or are variants, e.g. `裏`.
+const traditionalCharacters = `
+髮发 乾干 麵面 麪面 颱台 檯台 隻只 係系 繫系 鍾钟 複复 曆历 沖冲 鬆松 徵征 錶表 捲卷 儘尽 穫获 穀谷
+誌志 緻致 遊游 週周 捨舍 兇凶 鞦秋 韆千 睏困 籤签 鬚须 鬍胡 衚胡 衕同 傢家 颳刮 籲吁 嶽岳 紮扎 託托
+桿杆 闆板 餚肴 蕓芸 纔才 瞭了 製制 麽么 裏里 箇个 爲为 衆众 羣群 啓启 綫线 牀床 嚮向 閑闲 祕秘 峯峰
+鷄鸡 銹锈 併并 佈布 彙汇 噁恶 迴回 贊赞 鑑鉴 鍊链 藉借 範范 饑饥 菸烟 擡抬 脣唇 綵彩 鬨哄 犛牦 妳你
+衹只 牠它 甦苏 蹟迹 跡迹 纍累 喫吃
+`
+
+// simplifiedPhrases are phrases whose characters are converted otherwise, e.g. `头发` to `頭髮` rather than `頭發`.
+// Some longer phrases only guard against wrong matches across words, e.g. `这只是` against `這隻`.
+const simplifiedPhrases = `
+头发 頭髮
+头发现 頭發現
+头发生 頭發生
+头发出 頭發出
+头发展 頭發展
+理发 理髮
+白发 白髮
+白发生 白發生
+白发现 白發現
+黑发 黑髮
+金发 金髮
+金发现 金發現
+金发放 金發放
+金发行 金發行
+金发展 金發展
+银发 銀髮
+长发 長髮
+长发展 長發展
+长发育 長發育
+长发现 長發現
+长发生 長發生
+短发 短髮
+秀发 秀髮
+发丝 髮絲
+毛发 毛髮
+发型 髮型
+发髻 髮髻
+发簪 髮簪
+发梢 髮梢
+发夹 髮夾
+发带 髮帶
+发饰 髮飾
+发廊 髮廊
+鬓发 鬢髮
+披发 披髮
+削发 削髮
+束发 束髮
+染发 染髮
+烫发 燙髮
+假发 假髮
+毫发 毫髮
+须发 鬚髮
+卷发 捲髮
+怒发冲冠 怒髮衝冠
+令人发指 令人髮指
+千钧一发 千鈞一髮
+干净 乾淨
+干干净净 乾乾淨淨
+一干二净 一乾二淨
+干燥 乾燥
+干杯 乾杯
+饼干 餅乾
+干脆 乾脆
+干枯 乾枯
+干涸 乾涸
+干瘪 乾癟
+干旱 乾旱
+晒干 曬乾
+烘干 烘乾
+擦干 擦乾
+吹干 吹乾
+风干 風乾
+口干 口乾
+干粮 乾糧
+干爹 乾爹
+干妈 乾媽
+干咳 乾咳
+干笑 乾笑
+干瞪眼 乾瞪眼
+干货 乾貨
+干柴 乾柴
+干草 乾草
+干巴巴 乾巴巴
+干裂 乾裂
+外强中干 外強中乾
+干涉 干涉
+干预 干預
+干扰 干擾
+干戈 干戈
+相干 相干
+若干 若干
+干系 干係
+天干 天干
+皇后 皇后
+王后 王后
+太后 太后
+后妃 后妃
+天后 天后
+影后 影后
+母后 母后
+后土 后土
+公里 公里
+英里 英里
+海里 海里
+里程 里程
+千里 千里
+万里 萬里
+百里 百里
+十里 十里
+数里 數里
+故里 故里
+邻里 鄰里
+乡里 鄉里
+里长 里長
+面条 麵條
+面包 麵包
+面粉 麵粉
+面食 麵食
+面馆 麵館
+面团 麵團
+面筋 麵筋
+拉面 拉麵
+汤面 湯麵
+炒面 炒麵
+挂面 掛麵
+凉面 涼麵
+方便面 方便麵
+吃面 吃麵
+一碗面 一碗麵
+台风 颱風
+柜台 櫃檯
+台球 檯球
+吧台 吧檯
+写字台 寫字檯
+梳妆台 梳妝檯
+台灯 檯燈
+一只 一隻
+两只 兩隻
+三只 三隻
+四只 四隻
+五只 五隻
+几只 幾隻
+每只 每隻
+这只 這隻
+那只 那隻
+这只是 這只是
+那只是 那只是
+这只有 這只有
+那只有 那只有
+这只能 這只能
+那只能 那只能
+这只要 這只要
+那只要 那只要
+这只好 這只好
+那只好 那只好
+这只会 這只會
+那只会 那只會
+一只是 一只是
+船只 船隻
+只身 隻身
+只言片语 隻言片語
+形单影只 形單影隻
+关系 關係
+联系 聯繫
+维系 維繫
+系上 繫上
+系着 繫著
+系好 繫好
+系紧 繫緊
+系住 繫住
+牵系 牽繫
+确系 確係
+钟情 鍾情
+钟爱 鍾愛
+复杂 複雜
+重复 重複
+复制 複製
+复习 複習
+复数 複數
+复合 複合
+复印 複印
+繁复 繁複
+复姓 複姓
+复眼 複眼
+复述 複述
+复查 複查
+复核 複核
+复试 複試
+复赛 複賽
+反复 反覆
+答复 答覆
+回复 回覆
+日历 日曆
+农历 農曆
+阳历 陽曆
+阴历 陰曆
+公历 公曆
+挂历 掛曆
+历法 曆法
+冲洗 沖洗
+冲茶 沖茶
+冲水 沖水
+冲凉 沖涼
+冲泡 沖泡
+冲刷 沖刷
+冲淡 沖淡
+冲积 沖積
+冲喜 沖喜
+冲澡 沖澡
+冲冲 沖沖
+批准 批准
+准许 准許
+不准 不准
+不准确 不準確
+准予 准予
+获准 獲准
+核准 核准
+恩准 恩准
+准奏 准奏
+放松 放鬆
+轻松 輕鬆
+松开 鬆開
+松了 鬆了
+宽松 寬鬆
+松懈 鬆懈
+蓬松 蓬鬆
+松散 鬆散
+松动 鬆動
+松弛 鬆弛
+松口 鬆口
+松手 鬆手
+松绑 鬆綁
+松软 鬆軟
+松垮 鬆垮
+稀松 稀鬆
+特征 特徵
+象征 象徵
+表征 表徵
+征兆 徵兆
+征求 徵求
+征收 徵收
+征兵 徵兵
+征集 徵集
+征召 徵召
+征税 徵稅
+征询 徵詢
+征用 徵用
+征婚 徵婚
+征象 徵象
+手表 手錶
+钟表 鐘錶
+怀表 懷錶
+腕表 腕錶
+金表 金錶
+表带 錶帶
+表盘 錶盤
+卷起 捲起
+席卷 席捲
+卷入 捲入
+卷曲 捲曲
+卷走 捲走
+卷住 捲住
+卷成 捲成
+卷着 捲著
+卷土重来 捲土重來
+尽管 儘管
+尽量 儘量
+尽快 儘快
+尽早 儘早
+尽可能 儘可能
+借口 藉口
+凭借 憑藉
+收获 收穫
+稻谷 稻穀
+谷物 穀物
+五谷 五穀
+谷子 穀子
+谷仓 穀倉
+谷类 穀類
+杂志 雜誌
+标志 標誌
+日志 日誌
+墓志铭 墓誌銘
+精致 精緻
+细致 細緻
+别致 別緻
+雅致 雅緻
+景致 景緻
+游戏 遊戲
+旅游 旅遊
+游览 遊覽
+游客 遊客
+游玩 遊玩
+游荡 遊蕩
+游历 遊歷
+游行 遊行
+游侠 遊俠
+游说 遊說
+游子 遊子
+游乐 遊樂
+云游 雲遊
+周游 周遊
+一周 一週
+每周 每週
+上周 上週
+下周 下週
+本周 本週
+周末 週末
+周年 週年
+周期 週期
+周岁 週歲
+周刊 週刊
+周一 週一
+周二 週二
+周三 週三
+周四 週四
+周五 週五
+周六 週六
+周日 週日
+占卜 占卜
+占星 占星
+占卦 占卦
+舍弃 捨棄
+不舍 不捨
+舍不得 捨不得
+舍得 捨得
+施舍 施捨
+取舍 取捨
+舍身 捨身
+舍命 捨命
+割舍 割捨
+难舍 難捨
+四舍 四捨
+凶手 兇手
+凶狠 兇狠
+凶猛 兇猛
+凶残 兇殘
+凶恶 兇惡
+凶器 兇器
+凶暴 兇暴
+凶悍 兇悍
+凶案 兇案
+凶杀 兇殺
+凶徒 兇徒
+凶神恶煞 兇神惡煞
+行凶 行兇
+元凶 元兇
+帮凶 幫兇
+逞凶 逞兇
+小丑 小丑
+丑时 丑時
+丑角 丑角
+浓郁 濃郁
+馥郁 馥郁
+咸阳 咸陽
+咸丰 咸豐
+咸宜 咸宜
+犯困 犯睏
+困倦 睏倦
+困意 睏意
+划船 划船
+划算 划算
+划桨 划槳
+划水 划水
+划拳 划拳
+划得来 划得來
+划不来 划不來
+牙签 牙籤
+标签 標籤
+书签 書籤
+抽签 抽籤
+求签 求籤
+竹签 竹籤
+胡须 鬍鬚
+胡子 鬍子
+胡茬 鬍茬
+胡同 衚衕
+须眉 鬚眉
+触须 觸鬚
+根须 根鬚
+龙须 龍鬚
+伙食 伙食
+伙房 伙房
+家伙 傢伙
+家具 傢俱
+刮风 颳風
+刮大风 颳大風
+呼吁 呼籲
+山岳 山嶽
+五岳 五嶽
+包扎 包紮
+扎营 紮營
+驻扎 駐紮
+扎根 紮根
+扎实 紮實
+委托 委託
+托付 託付
+寄托 寄託
+拜托 拜託
+推托 推託
+嘱托 囑託
+托梦 託夢
+托词 託詞
+信托 信託
+枪杆 槍桿
+笔杆 筆桿
+杠杆 槓桿
+秤杆 秤桿
+老板 老闆
+菜肴 菜餚
+佳肴 佳餚
+了解 瞭解
+除了 除了
+为了 為了
+明了 明瞭
+了如指掌 瞭如指掌
+一目了然 一目瞭然
+了望 瞭望
+制造 製造
+制作 製作
+制品 製品
+制成 製成
+制药 製藥
+制剂 製劑
+绘制 繪製
+研制 研製
+缝制 縫製
+炼制 煉製
+定制 訂製
+监制 監製
+仿制 仿製
+特制 特製
+精制 精製
+配制 配製
+印制 印製
+调制 調製
+炮制 炮製
+范围 範圍
+规范 規範
+示范 示範
+模范 模範
+范例 範例
+范畴 範疇
+防范 防範
+典范 典範
+范本 範本
+范文 範文
+风范 風範
+师范 師範
+就范 就範
+北斗 北斗
+南斗 南斗
+斗笠 斗笠
+熨斗 熨斗
+漏斗 漏斗
+星斗 星斗
+斗篷 斗篷
+烟斗 菸斗
+八斗 八斗
+斗室 斗室
+斗转星移 斗轉星移
+泰斗 泰斗
+筋斗 筋斗
+人云亦云 人云亦云
+云云 云云
+秋千 鞦韆
+饥荒 饑荒
+饥馑 饑饉
+赞助 贊助
+赞成 贊成
+赞同 贊同
+`
+
+// traditionalPhrases are phrases whose characters are converted otherwise, e.g. `著名` keeps `著` rather than `着`.
+const traditionalPhrases = `
+乾坤 乾坤
+乾隆 乾隆
+著名 著名
+著作 著作
+著稱 著称
+著述 著述
+著者 著者
+顯著 显著
+卓著 卓著
+昭著 昭著
+名著 名著
+原著 原著
+巨著 巨著
+論著 论著
+編著 编著
+譯著 译著
+專著 专著
+遺著 遗著
+合著 合著
+拙著 拙著
+土著 土著
+反覆 反复
+答覆 答复
+回覆 回复
+覆蓋 覆盖
+顛覆 颠覆
+覆滅 覆灭
+覆沒 覆没
+覆水 覆水
+翻覆 翻覆
+狼藉 狼藉
+慰藉 慰藉
+枕藉 枕藉
+瞭望 瞭望
+`
@@ -0,0 +1,76 @@
+// choose, interactive selection of auto-detected catalogs
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+const (
+	choosePrompt        = "Choose catalogs by No., separated by comma (e.g. 1,3). Press Enter to use the preferred ones(*): "
+	chooseInvalidPrompt = "Invalid Choice %q. Try again: "
+)
+
+// printCandidates list candidates with hostname, amount of chapters, first/last chapter and cluster.
+// @param latest map[string]string names of the latest chapters listed by novel sites, by urls of candidates. See `search.Latests`.
+func printCandidates(writer io.Writer, candidates []*extract.Candidate, latest map[string]string) {
+	fmt.Fprintf(writer, "Candidate Catalogs:\n")
+	for i, c := range candidates {
+		mark := " "
+		if c.Preferred {
+			mark = "*"
+		}
+		var first, last string
+		if len(c.Chapters) > 0 {
+			first, last = c.Chapters[0].Name, c.Chapters[len(c.Chapters)-1].Name
+		}
+		fmt.Fprintf(writer, "%s%3d  Cluster %d  %s  %d chapters\n", mark, i+1, c.Cluster+1, c.Hostname, len(c.Chapters))
+		fmt.Fprintf(writer, "        First: %s\n        Last:  %s\n", first, last)
+		if l, ok := latest[c.Url]; ok {
+			fmt.Fprintf(writer, "        Latest on site: %s\n", l)
+		}
+		fmt.Fprintf(writer, "        %s\n", c.Url)
+	}
+}
+
+// parseChoice parse input in the form of `1,3, 5`. Indexes are 1-based in input and 0-based in output.
+// Repeated indexes are kept once in the order of input.
+func parseChoice(input string, maximum int) ([]int, error) {
+	var rets []int
+	chosen := make(map[int]bool)
+	for _, field := range strings.Split(input, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		index, err := strconv.Atoi(field)
+		if err != nil || index <= 0 || index > maximum {
+			return nil, errInvalidSelection
+		}
+		if !chosen[index] {
+			chosen[index] = true
+			rets = append(rets, index-1)
+		}
+	}
+	return rets, nil
+}
+
+// chooseCandidates ask the user which candidates to use.
+func chooseCandidates(candidates []*extract.Candidate, latest map[string]string) []int {
+	printCandidates(os.Stdout, candidates, latest)
+	fmt.Fprintf(os.Stdout, "%s", choosePrompt)
+	reader := bufio.NewScanner(os.Stdin)
+	for reader.Scan() {
+		choice, err := parseChoice(reader.Text(), len(candidates))
+		if err == nil {
+			return choice
+		}
+		fmt.Fprintf(os.Stdout, chooseInvalidPrompt, reader.Text())
+	}
+	return nil
+}
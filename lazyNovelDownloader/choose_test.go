@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChoice(t *testing.T) {
+	type Data struct {
+		input   string
+		expect  []int
+		invalid bool
+	}
+	data := []Data{
+		Data{"1,3, 5", []int{0, 2, 4}, false},
+		Data{" 2 ", []int{1}, false},
+		Data{"3,1,3,1", []int{2, 0}, false},
+		Data{"1,,2,", []int{0, 1}, false},
+		Data{"", nil, false},
+		Data{" , ", nil, false},
+		Data{"0", nil, true},
+		Data{"6", nil, true},
+		Data{"1,-2", nil, true},
+		Data{"a", nil, true},
+		Data{"1 3", nil, true},
+	}
+	for _, d := range data {
+		ret, err := parseChoice(d.input, 5)
+		if !reflect.DeepEqual(ret, d.expect) || (err != nil) != d.invalid {
+			t.Errorf("Get %v, %v from %q. Expect %v, invalid: %v.\n", ret, err, d.input, d.expect, d.invalid)
+		}
+	}
+}
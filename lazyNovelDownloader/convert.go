@@ -0,0 +1,60 @@
+// convert, sub command of lnd rendering a downloaded novel in another format without fetching again
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/typography"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/write"
+)
+
+const (
+	convertCommand       = "convert"
+	convertInvalidPrompt = "Invalid Arguments! Usage: `lnd convert [flags] <file>`, where format of file is told by its extension. Type in `lnd convert -h` for help."
+)
+
+// convert read a file written by lnd, and write it in another format.
+func convert(args []string) {
+	flags := flag.NewFlagSet(convertCommand, flag.ExitOnError)
+	format := flags.String("format", "epub", "[optional] "+strings.Join(write.Formats, "/"))
+	output := flags.String("o", "", "[optional] Output File Name(can include path), default to input without extension")
+	provenance := flags.Bool("provenance", false, "[optional] Whether to record where every chapter comes from")
+	script := flags.String("chinese", "", "[optional] Convert names and contents to traditional/simplified Chinese")
+	spec := flags.String("typography", "", "[optional] Normalize punctuations, e.g. default, or items of full/half,curly/corner,merge,ellipsis,dash")
+	flags.Parse(args)
+	// NOTICE: Flags may also follow the file, e.g. `lnd convert in.json -format epub`.
+	var input string
+	if flags.NArg() > 0 {
+		input = flags.Arg(0)
+		flags.Parse(flags.Args()[1:])
+	}
+	normalizer, err := typography.Parse(*spec)
+	if input == "" || flags.NArg() > 0 || !validChinese(*script) || err != nil {
+		log.Fatalf("%s", convertInvalidPrompt)
+	}
+
+	chapters, novelInfo, err := write.Read(input)
+	if err != nil {
+		log.Fatalf("While reading "+input+errorPrompt, err)
+	}
+	// NOTICE: .txt keeps only the URL of cover.
+	if *format != "txt" {
+		if err = novelInfo.FetchCover(); err != nil {
+			fmt.Printf("NOTICE: Fail to fetch cover from %s: %v.\n", novelInfo.CoverURL, err)
+		}
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(input, filepath.Ext(input))
+	}
+	if *output, err = filepath.Abs(*output); err != nil {
+		log.Fatalf("While getting output file path"+errorPrompt, err)
+	}
+	if err = write.Write(os.Stdout, *format, chapters, *output, novelInfo, &write.WriteOption{Provenance: *provenance, Chinese: chineseConverter(*script), Typography: normalizer}); err != nil {
+		log.Fatalf("While writing to file"+errorPrompt, err)
+	}
+}
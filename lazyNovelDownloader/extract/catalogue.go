@@ -2,19 +2,40 @@
 package extract
 
 import (
-	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/RaymondJiangkw/Lazy/utils"
 	"golang.org/x/net/html"
 )
 
 type Chapter struct {
-	Name    string
-	Url     string
-	Content string
-	Fetch   bool
+	Name    string `json:"name"`
+	Url     string `json:"url"`
+	Content string `json:"content"`
+	Fetch   bool   `json:"fetch"`
+	// Problem is given by `ValidateContents` when content is suspicious. Empty means fine.
+	Problem string `json:"problem,omitempty"`
+	// Score is given by `QualityScorer` after fetching, see `MergeCatalogBy`.
+	Score float64 `json:"score"`
+	// Quality is the breakdown of Score, given by `QualityReporter`. nil means unknown.
+	Quality *Quality `json:"quality,omitempty"`
+	// Source is the hostname where content is fetched.
+	Source    string    `json:"source,omitempty"`
+	FetchTime time.Time `json:"fetch_time"`
+	// Hash is given by `ContentHash` after cleaning.
+	Hash string `json:"hash,omitempty"`
+	// Catalogues are urls of catalogues having the chapter, given by merging. See `MergeReport`.
+	Catalogues []string `json:"catalogues,omitempty"`
+}
+
+// ContentHash give SHA-1 of content in hex, which tells whether two contents are the same.
+func ContentHash(content string) string {
+	sum := sha1.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
 }
 
 type Chapters []*Chapter
@@ -23,8 +44,32 @@ func (c *Chapter) String() string {
 	return "Name: " + c.Name + " Url: " + c.Url
 }
 
+// Equal compare chapters by parsed titles, see `ParseTitle`.
 func (c *Chapter) Equal(c_a *Chapter) bool {
-	return c.Name == c_a.Name
+	return ParseTitle(c.Name).Key() == ParseTitle(c_a.Name).Key()
+}
+
+// Methods used by `Catalogue` to locate chapters.
+const (
+	MethodDL  = "dl"
+	MethodUL  = "ul"
+	MethodDiv = "div"
+)
+
+// CatalogueReport record how a catalogue is extracted.
+type CatalogueReport struct {
+	Url    string
+	Method string // One of MethodDL, MethodUL, MethodDiv.
+	// Chapters are what `Catalogue` returns.
+	Chapters Chapters
+	// Duplicates are chapters removed since the same name or number appears later.
+	Duplicates Chapters
+	// Moved is the number of chapters put back in order, see `RepairOrder`.
+	Moved int
+	// Gaps are chapter numbers missing in Chapters.
+	Gaps []Gap
+	// Metadata is the information of novel on the page.
+	Metadata *Metadata
 }
 
 // Catalogue give the catalogue in the url.
@@ -32,13 +77,39 @@ func (c *Chapter) Equal(c_a *Chapter) bool {
 // However, not all websites use this mechanism. So, there is another
 // method of getting the most <a> Tags under a <div> Tag.
 func Catalogue(url string) (c Chapters, e error) {
+	r, e := InspectCatalogue(url)
+	if e != nil {
+		return nil, e
+	}
+	return r.Chapters, nil
+}
+
+// InspectCatalogue works the same as `Catalogue`, but also reports the matched method, removed duplications and repaired order.
+func InspectCatalogue(url string) (*CatalogueReport, error) {
+	return inspectCatalogue(url, true)
+}
+
+// InspectCachedCatalogue works the same as `InspectCatalogue`, but reads the page from cache if exists,
+// e.g. the one just fetched by `search.Search` to verify it.
+func InspectCachedCatalogue(url string) (*CatalogueReport, error) {
+	return inspectCatalogue(url, false)
+}
+
+// @param refresh bool whether to bypass cache.
+func inspectCatalogue(url string, refresh bool) (r *CatalogueReport, e error) {
+	// Redirectors of search engines hide the real page.
+	if utils.IsRedirectURL(url) {
+		if url, e = utils.ResolveURL(url, 0); e != nil {
+			return nil, e
+		}
+	}
 	// NOTICE: This is a brute action to speed up.
 	// We only accept folder or `index` here.
 	if strings.ToLower(utils.PageNameURL(url)) != "index" && strings.Index(path.Base(url), ".") != -1 {
 		return nil, utils.Invalid
 	}
 
-	bodies, errs, ioCompletes := utils.Fetch([]string{url}, &utils.FetchOption{Redirect: true, Refresh: true})
+	bodies, errs, ioCompletes := utils.Fetch([]string{url}, &utils.FetchOption{Redirect: true, Refresh: refresh})
 	defer utils.WaitSync(ioCompletes) // Sync I/O here, since `catalogue` only has one page.
 	if errs[0] != nil {
 		e = errs[0]
@@ -50,16 +121,20 @@ func Catalogue(url string) (c Chapters, e error) {
 		return
 	}
 
+	r = &CatalogueReport{Url: url, Metadata: ParseMetadata(doc, url)}
 	var aTags []utils.TagA
 	if aTags = utils.ParseATags(extractAUnderDL(doc)); len(aTags) > 0 {
 		// Method 1
 		// Find <a> under <dl>
+		r.Method = MethodDL
 	} else if aTags = utils.ParseATags(extractAUnderUL(doc)); len(aTags) > 0 {
 		// Method 2
 		// Find <a> under <ul>
+		r.Method = MethodUL
 	} else if aTags = utils.ParseATags(mostAUnderDiv(doc)); len(aTags) > 0 {
 		// Method 3
 		// Find the most <a> under <div>
+		r.Method = MethodDiv
 	} else {
 		return nil, utils.Invalid
 	}
@@ -98,10 +173,18 @@ func Catalogue(url string) (c Chapters, e error) {
 			}
 		*/
 		if exists[tmp[i].Name] == 1 {
-			c = append(c, tmp[i])
+			r.Chapters = append(r.Chapters, tmp[i])
+		} else {
+			r.Duplicates = append(r.Duplicates, tmp[i])
 		}
 		exists[tmp[i].Name]--
 	}
+	// NOTICE: Numbers in names are more reliable than positions, when most chapters have them.
+	var order *OrderReport
+	r.Chapters, order = RepairOrder(r.Chapters)
+	r.Duplicates = append(r.Duplicates, order.Duplicates...)
+	r.Moved = order.Moved
+	r.Gaps = r.Chapters.Gaps()
 	return
 }
 
@@ -126,99 +209,112 @@ func mostAUnderDiv(root *html.Node) (ret []*html.Node) {
 	return
 }
 
-func ValidCatalog(c_s []Chapters) []Chapters {
-	if len(c_s) <= 1 {
-		return c_s
+// ClusterCatalog partition catalogues into groups of similar ones, and determine the group preferred by `ValidCatalog`.
+// @return groups [][]int indexes of c_s in each group.
+func ClusterCatalog(c_s []Chapters) (groups [][]int, preferred int) {
+	if len(c_s) == 0 {
+		return nil, 0
+	}
+	if len(c_s) == 1 {
+		return [][]int{[]int{0}}, 0
 	}
 	// Prepare Partition
 	nameGroups := make([][]string, len(c_s), len(c_s))
 	data := make([]*utils.StringSlices, len(c_s), len(c_s))
-	Names2Chapter := make(map[*[]string]Chapters)
+	Names2Index := make(map[*utils.StringSlices]int)
 	for i, c := range c_s {
-		nameGroups[i] = make([]string, len(c), len(c))
-		for j, d := range c {
-			nameGroups[i][j] = d.Name
-		}
+		nameGroups[i] = c.Keys() // NOTICE: Match by parsed titles, since formats vary among sites.
 		data[i] = (*utils.StringSlices)(&nameGroups[i])
-		Names2Chapter[&nameGroups[i]] = c
+		Names2Index[data[i]] = i
+	}
+	partitions, _ := utils.PartitionStringSlices(data, 0.5)
+	groups = make([][]int, len(partitions), len(partitions))
+	for i, partition := range partitions {
+		for _, d := range partition {
+			groups[i] = append(groups[i], Names2Index[d])
+		}
 	}
-	groups, _ := utils.PartitionStringSlices(data, 0.5)
 	// Find Appropriate Group
-	maximumGroupIndex := 0
-	var cntLength = func(d []*utils.StringSlices) int {
+	var cntLength = func(d []int) int {
 		ret := 0
 		for _, _d := range d {
-			ret += len(*_d)
+			ret += len(c_s[_d])
 		}
 		return ret
 	}
 	for i := 1; i < len(groups); i++ {
 		avgLengthI := cntLength(groups[i]) / len(groups[i])
-		avgLengthMax := cntLength(groups[maximumGroupIndex]) / len(groups[maximumGroupIndex])
+		avgLengthMax := cntLength(groups[preferred]) / len(groups[preferred])
 		if avgLengthI > avgLengthMax { // NOTICE: prefer longer urls first.
-			maximumGroupIndex = i
+			preferred = i
 		} else if avgLengthI == avgLengthMax {
-			if len(groups[i]) > len(groups[maximumGroupIndex]) {
-				maximumGroupIndex = i
+			if len(groups[i]) > len(groups[preferred]) {
+				preferred = i
 			}
 		}
 	}
+	return
+}
+
+func ValidCatalog(c_s []Chapters) []Chapters {
+	if len(c_s) <= 1 {
+		return c_s
+	}
+	groups, preferred := ClusterCatalog(c_s)
 	// Reconstruct Output
-	rets := make([]Chapters, len(groups[maximumGroupIndex]), len(groups[maximumGroupIndex]))
-	for i := 0; i < len(groups[maximumGroupIndex]); i++ {
-		rets[i] = Names2Chapter[(*[]string)(groups[maximumGroupIndex][i])]
+	rets := make([]Chapters, len(groups[preferred]), len(groups[preferred]))
+	for i, index := range groups[preferred] {
+		rets[i] = c_s[index]
 	}
 	return rets
 }
 
-func contentQualityOver(u, v *Chapter) *Chapter {
+// contentQualityOver choose the better one of two chapters, and record their scores if not scored yet, e.g. by `Extract`.
+func contentQualityOver(u, v *Chapter, scorer QualityScorer) *Chapter {
 	const ratio = 0.8
-	const minimumTextLength = 10
-	// Test 1: Length. We prefer longer length.
-	if float64(len(u.Content))/float64(len(v.Content)) < ratio {
-		return v
-	} else if float64(len(v.Content))/float64(len(u.Content)) < ratio {
-		return u
-	}
-	// Test 2: Short Lines. We prefer less short lines.
-	shortLineNumbers := func(s *string) int {
-		ret := 0
-		r := bufio.NewScanner(strings.NewReader(*s))
-		for r.Scan() {
-			if len(r.Text()) < minimumTextLength {
-				ret++
-			}
+	for _, c := range []*Chapter{u, v} {
+		if c.Fetch && c.Score == 0 && c.Quality == nil {
+			scoreChapter(c, scorer)
 		}
-		return ret
 	}
-	uShortLineNumbers := shortLineNumbers(&u.Content)
-	vShortLineNumbers := shortLineNumbers(&v.Content)
-	if uShortLineNumbers > vShortLineNumbers {
+	// Test 0: Fetched and not suspicious ones are preferred.
+	if u.Fetch != v.Fetch {
+		if u.Fetch {
+			return u
+		}
 		return v
-	} else if vShortLineNumbers > uShortLineNumbers {
-		return u
 	}
-	// Test 3: Weird Symbols. We prefer less weird symbols.
-	symbols := []string{"&", ";", "(", ")", "~", "@", "#", "%", "^", "*", "-", "+", "http", ":", "/", "<", ">"}
-	symbolsCount := func(s *string) int {
-		ret := 0
-		for _, symbol := range symbols {
-			ret += strings.Count(*s, symbol)
+	if (u.Problem == "") != (v.Problem == "") {
+		if u.Problem == "" {
+			return u
 		}
-		return ret
+		return v
 	}
-	uSymbolsNum := symbolsCount(&u.Content)
-	vSymbolsNum := symbolsCount(&v.Content)
-	if uSymbolsNum > vSymbolsNum {
+	// Test 1: Length of texts. We prefer longer length, since content may be truncated.
+	if uLen, vLen := float64(len(withoutImages(u.Content))), float64(len(withoutImages(v.Content))); uLen/vLen < ratio {
 		return v
-	} else if vSymbolsNum > uSymbolsNum {
+	} else if vLen/uLen < ratio {
 		return u
 	}
+	// Test 2: Score. We prefer higher score.
+	if v.Score > u.Score {
+		return v
+	}
 	// Otherwise, return the first.
 	return u
 }
 
+// MergeCatalog merge two catalogues, matching chapters by parsed titles. See `ParseTitle`.
 func MergeCatalog(u, v Chapters) (rets Chapters) {
+	return MergeCatalogBy(u, v, nil)
+}
+
+// MergeCatalogBy works the same as `MergeCatalog`, but scores contents of the same chapter by scorer.
+// @param scorer QualityScorer (default: DefaultScorer)
+func MergeCatalogBy(u, v Chapters, scorer QualityScorer) (rets Chapters) {
+	if scorer == nil {
+		scorer = DefaultScorer
+	}
 	// Special Cases
 	if u == nil {
 		return v
@@ -226,22 +322,20 @@ func MergeCatalog(u, v Chapters) (rets Chapters) {
 	if v == nil {
 		return u
 	}
-	uNameMap := make(map[string]*Chapter)
-	vNameMap := make(map[string]*Chapter)
-	uNames := make([]string, len(u), len(u))
-	vNames := make([]string, len(v), len(v))
-	for _, c := range u {
-		uNameMap[c.Name] = c
-		uNames = append(uNames, c.Name)
+	uKeyMap := make(map[string]*Chapter)
+	vKeyMap := make(map[string]*Chapter)
+	uKeys := u.Keys()
+	vKeys := v.Keys()
+	for i, c := range u {
+		uKeyMap[uKeys[i]] = c
 	}
-	for _, c := range v {
-		vNameMap[c.Name] = c
-		vNames = append(vNames, c.Name)
+	for i, c := range v {
+		vKeyMap[vKeys[i]] = c
 	}
-	mergedNames := utils.IntegrateStringSlices(uNames, vNames)
-	for _, n := range mergedNames {
-		c_1, ok_1 := uNameMap[n]
-		c_2, ok_2 := vNameMap[n]
+	mergedKeys := utils.IntegrateStringSlices(uKeys, vKeys)
+	for _, n := range mergedKeys {
+		c_1, ok_1 := uKeyMap[n]
+		c_2, ok_2 := vKeyMap[n]
 		// Case 1 only One Has
 		if ok_1 == true && ok_2 == false {
 			rets = append(rets, c_1)
@@ -250,7 +344,7 @@ func MergeCatalog(u, v Chapters) (rets Chapters) {
 		}
 		// Case 2 both Have
 		if ok_1 && ok_2 {
-			rets = append(rets, contentQualityOver(c_1, c_2))
+			rets = append(rets, contentQualityOver(c_1, c_2, scorer))
 		}
 	}
 	return
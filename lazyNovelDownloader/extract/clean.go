@@ -0,0 +1,178 @@
+// clean remove ads and watermarks from contents of chapters.
+package extract
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+const (
+	defaultRepeatRatio = 0.3
+	// Repeated lines are only detected among enough chapters, and long enough lines.
+	minimumRepeatChapters   = 5
+	minimumRepeatLineLength = 10 // in runes
+	rulePrefixRegexp        = "re:"
+	rulePrefixLine          = "line:"
+	ruleComment             = "#"
+)
+
+// builtinPatterns match ads and watermarks common among pirate mirrors.
+var builtinPatterns = []string{
+	`天才一秒记住本站地址[：:]?\S*`,
+	`一秒记住[【\[].*?[】\]]\S*`,
+	`手机版阅读网址[：:]?\S*`,
+	`手机用户请浏览\S*阅读\S*`,
+	`请收藏本站[：:]?\S*`,
+	`请记住本书首发域名[：:]?\S*`,
+	`笔趣阁手机版[：:]?\S*`,
+	`最快更新\S*最新章节[！!]?`,
+	`本章未完，请点击下一页继续阅读[。]?`,
+	// Obfuscated domains, e.g. `ｗｗｗ．ｘｘｘ．ｃｏｍ`, `www点xxx点com`
+	`(?i)[wｗ]{3}\s*[.．。点]\s*[a-z0-9ａ-ｚ０-９]+\s*[.．。点]\s*(?:com|net|org|cc|la|co|me|info|ｃｏｍ|ｎｅｔ|ｏｒｇ|ｃｃ|ｌａ)`,
+}
+
+// Cleaner remove lines of ads and watermarks.
+// Matches of Patterns are deleted from lines, and lines left with nothing but punctuations are removed.
+// Lines equal to one of Lines, or appearing in more than RepeatRatio of chapters, are removed as well.
+type Cleaner struct {
+	Patterns []*regexp.Regexp
+	Lines    map[string]bool
+	// RepeatRatio is the ratio of chapters, above which a line is perceived as boilerplate. 0 disables it.
+	RepeatRatio float64
+}
+
+// NewCleaner create Cleaner with built-in rules.
+func NewCleaner() *Cleaner {
+	c := &Cleaner{Lines: make(map[string]bool), RepeatRatio: defaultRepeatRatio}
+	for _, p := range builtinPatterns {
+		c.Patterns = append(c.Patterns, regexp.MustCompile(p))
+	}
+	return c
+}
+
+// AddRules add rules line by line. Lines starting with `re:` are regular expressions, and `#` comments.
+// Others, optionally starting with `line:`, are exact lines.
+func (c *Cleaner) AddRules(rules string) error {
+	r := bufio.NewScanner(strings.NewReader(rules))
+	for r.Scan() {
+		rule := strings.TrimSpace(r.Text())
+		switch {
+		case rule == "" || strings.HasPrefix(rule, ruleComment):
+		case strings.HasPrefix(rule, rulePrefixRegexp):
+			p, err := regexp.Compile(strings.TrimPrefix(rule, rulePrefixRegexp))
+			if err != nil {
+				return err
+			}
+			c.Patterns = append(c.Patterns, p)
+		default:
+			c.Lines[strings.TrimSpace(strings.TrimPrefix(rule, rulePrefixLine))] = true
+		}
+	}
+	return nil
+}
+
+// LoadRules add rules in file. See `AddRules` for its format.
+func (c *Cleaner) LoadRules(filePath string) error {
+	rules, err := utils.ReadFileString(filePath)
+	if err != nil {
+		return err
+	}
+	return c.AddRules(rules)
+}
+
+// isEmptyLine tells whether line has nothing but spaces and punctuations.
+func isEmptyLine(line string) bool {
+	for _, r := range line {
+		if !unicode.IsSpace(r) && !unicode.IsPunct(r) && !unicode.IsSymbol(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// lines split formatted content into trimmed lines.
+func lines(content string) (rets []string) {
+	r := bufio.NewScanner(strings.NewReader(content))
+	for r.Scan() {
+		if line := strings.TrimSpace(r.Text()); line != "" {
+			rets = append(rets, line)
+		}
+	}
+	return
+}
+
+// CleanText clean a single content by patterns and exact lines.
+func (c *Cleaner) CleanText(content string) string {
+	return c.cleanText(content, nil)
+}
+
+func (c *Cleaner) cleanText(content string, repeated map[string]bool) string {
+	var b strings.Builder
+	b.Grow(len(content))
+	for _, line := range lines(content) {
+		if c.Lines[line] || repeated[line] {
+			continue
+		}
+		cleaned := strings.TrimSpace(exceptImages(line, func(s string) string {
+			for _, p := range c.Patterns {
+				s = p.ReplaceAllString(s, "")
+			}
+			return s
+		}))
+		// NOTICE: Lines of punctuations only, e.g. `……`, are removed only if patterns leave them so.
+		if cleaned != line && isEmptyLine(cleaned) {
+			continue
+		}
+		b.WriteString(textPrefix)
+		b.WriteString(cleaned)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// repeatedLines find lines appearing in more than RepeatRatio of fetched chapters.
+func (c *Cleaner) repeatedLines(chapters Chapters) map[string]bool {
+	rets := make(map[string]bool)
+	if c.RepeatRatio <= 0 {
+		return rets
+	}
+	counts := make(map[string]int)
+	total := 0
+	for _, chapter := range chapters {
+		if !chapter.Fetch {
+			continue
+		}
+		total++
+		appears := make(map[string]bool)
+		for _, line := range lines(chapter.Content) {
+			if utf8.RuneCountInString(line) >= minimumRepeatLineLength && !appears[line] {
+				appears[line] = true
+				counts[line]++
+			}
+		}
+	}
+	if total < minimumRepeatChapters {
+		return rets
+	}
+	for line, cnt := range counts {
+		if float64(cnt) > float64(total)*c.RepeatRatio {
+			rets[line] = true
+		}
+	}
+	return rets
+}
+
+// Clean clean contents of fetched chapters in place.
+func (c *Cleaner) Clean(chapters Chapters) {
+	repeated := c.repeatedLines(chapters)
+	for _, chapter := range chapters {
+		if chapter.Fetch {
+			chapter.Content = c.cleanText(chapter.Content, repeated)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package extract_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func TestCleanText(t *testing.T) {
+	type Data struct {
+		content string
+		result  string
+	}
+	data := []Data{
+		Data{content: "    天才一秒记住本站地址：www.example.com\n    他推开了门。\n", result: "    他推开了门。\n"},
+		Data{content: "    夜色渐深。手机版阅读网址：m.example.com\n", result: "    夜色渐深。\n"},
+		Data{content: "    ｗｗｗ．ｅｘａｍｐｌｅ．ｃｏｍ\n    本章完\n", result: ""},
+		Data{content: "    ……\n    “……”\n    ！！！\n    ——\n", result: "    ……\n    “……”\n    ！！！\n    ——\n"},
+		Data{content: "    “一秒记住【www.example.com】”\n", result: ""},
+	}
+	c := extract.NewCleaner()
+	if err := c.AddRules("# comment\nre:^本章.*\n"); err != nil {
+		t.Fatalf("Add rules: %v\n", err)
+	}
+	for _, d := range data {
+		if ret := c.CleanText(d.content); ret != d.result {
+			t.Errorf("Get %q from %q. Expect %q.\n", ret, d.content, d.result)
+		}
+	}
+}
+
+func TestCleanRepeatedLines(t *testing.T) {
+	const boilerplate = "本书由某某文学网首发，转载请注明出处"
+	var chapters extract.Chapters
+	for i := 0; i < 10; i++ {
+		content := "    第" + strconv.Itoa(i) + "段正文，内容各不相同。\n"
+		if i%3 == 0 {
+			content += "    " + boilerplate + "\n"
+		}
+		chapters = append(chapters, &extract.Chapter{Name: strconv.Itoa(i), Content: content, Fetch: true})
+	}
+	extract.NewCleaner().Clean(chapters)
+	for _, c := range chapters {
+		if strings.Contains(c.Content, boilerplate) {
+			t.Errorf("Get %q. Expect boilerplate removed.\n", c.Content)
+		}
+		if !strings.Contains(c.Content, "正文") {
+			t.Errorf("Get %q. Expect body kept.\n", c.Content)
+		}
+	}
+}
@@ -16,13 +16,19 @@ const (
 	textPrefix = "    "
 )
 
-func content(body string) (content string, err error) {
+// ParseContent extract text of chapter in body of page in url. Images are kept as placeholders. See `ImageText`.
+func ParseContent(body string, url string) (content string, err error) {
 	doc, err := html.Parse(strings.NewReader(body))
 	if err != nil {
 		return
 	}
-	content = mostTextUnderDiv(doc)
+	images := markImages(doc, url)
+	content, ok := ReadableText(doc)
+	if !ok {
+		content = mostTextUnderDiv(doc)
+	}
 	content = formatString(&content)
+	content = unmarkImages(content, images)
 	return
 }
 
@@ -33,7 +39,8 @@ type ContentResult struct {
 
 // Content use async I/O.
 // ioCompletes must be wait after receiving from resultChan.
-func Content(urls []string, fetchSignal chan<- struct{}) (<-chan ContentResult, <-chan struct{}, []<-chan struct{}) {
+// @param refresh bool whether to bypass cache.
+func Content(urls []string, refresh bool, fetchSignal chan<- struct{}) (<-chan ContentResult, <-chan struct{}, []<-chan struct{}) {
 	var result ContentResult
 	var bodies []*string
 	var errs []error
@@ -45,7 +52,7 @@ func Content(urls []string, fetchSignal chan<- struct{}) (<-chan ContentResult,
 	tokens := make(chan struct{}, maximumRoutines)
 	go func() {
 		var wg sync.WaitGroup
-		bodies, errs, ioCompletes = utils.Fetch(urls, &utils.FetchOption{Redirect: true, Signal: fetchSignal})
+		bodies, errs, ioCompletes = utils.Fetch(urls, &utils.FetchOption{Redirect: true, Refresh: refresh, Signal: fetchSignal})
 		for i, body := range bodies {
 			wg.Add(1)
 			go func(i int, body *string) {
@@ -58,7 +65,7 @@ func Content(urls []string, fetchSignal chan<- struct{}) (<-chan ContentResult,
 				if errs[i] != nil {
 					result.Contents[i], result.Errs[i] = "", errs[i]
 				} else {
-					_content, _err := content(*bodies[i])
+					_content, _err := ParseContent(*bodies[i], urls[i])
 					result.Contents[i], result.Errs[i] = _content, _err
 				}
 				return
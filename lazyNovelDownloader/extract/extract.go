@@ -4,6 +4,7 @@ package extract
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/RaymondJiangkw/Lazy/utils"
@@ -14,46 +15,177 @@ const (
 	extractCacheFolder       = ".novel"
 )
 
-func Extract(writer io.Writer, urls []string, novelName string, validate bool, merge bool) ([]Chapters, []error) {
+// Candidate describe a valid catalogue found while validating.
+type Candidate struct {
+	Url      string
+	Hostname string
+	Chapters Chapters
+	// Cluster is the index of group given by `ClusterCatalog`.
+	Cluster int
+	// Preferred tells whether `ValidCatalog` would choose it.
+	Preferred bool
+}
+
+type ExtractOption struct {
+	// Selection is applied to every catalogue before fetching contents. nil means all chapters.
+	Selection *Selection
+	// Choose is called after validating, and returns indexes of candidates to use.
+	// Repeated indexes are used once. Empty return falls back to the preferred ones. nil means `ValidCatalog` decides.
+	Choose func(candidates []*Candidate) []int
+	// Cleaner is applied to every catalogue after fetching contents. nil means no cleaning.
+	Cleaner *Cleaner
+	// Fallback fetch every chapter only from the first catalogue having it, and turn to others
+	// only when it fails or is suspicious. It takes effect with merge. See `AlignCatalog`.
+	Fallback bool
+	// Scorer scores fetched contents, which decides the better content when merging. nil means `DefaultScorer`.
+	Scorer QualityScorer
+	// Cached reads catalogues from cache if exist, e.g. those just fetched by `search.Search` to verify them.
+	Cached bool
+	// Metadata is filled with information of novel on catalogues in use, where the first one having a field wins.
+	// nil skips it. See `ParseMetadata`.
+	Metadata *Metadata
+}
+
+// Extract fetch catalogues in urls and then contents of their chapters.
+// @param options *ExtractOption (default: keep all chapters)
+func Extract(writer io.Writer, urls []string, novelName string, validate bool, merge bool, options *ExtractOption) ([]Chapters, []error) {
 	var display utils.Display
+	if options == nil {
+		options = &ExtractOption{}
+	}
 	cnt := len(urls)
 	catalogueSignal := make(chan struct{}, cnt)
 	catalogues := make([]Chapters, cnt)
 	catalogueErrors := make([]error, cnt)
+	metadatas := make([]*Metadata, cnt)
 
 	for i, url := range urls {
 		go func(i int, url string) {
 			defer func() {
 				catalogueSignal <- struct{}{}
 			}()
-			catalogues[i], catalogueErrors[i] = Catalogue(url)
+			r, err := inspectCatalogue(url, !options.Cached)
+			if catalogueErrors[i] = err; err == nil {
+				catalogues[i], metadatas[i] = r.Chapters, r.Metadata
+			}
 		}(i, url)
 	}
 	finish, _ := display.EasyProgress(writer, "Fetching Catalogues", "...", len(urls), catalogueSignal) // NOTICE: Confident of Success
 	<-finish
+	var used []int        // Indexes of urls whose catalogues are in use.
+	catalogueUrls := urls // Urls of catalogues, recorded in chapters after merging.
+	for i := range urls {
+		if catalogueErrors[i] == nil {
+			used = append(used, i)
+		}
+	}
 	// Validating Catalogues
 	if signal := make(chan struct{}); validate {
 		// Exclude Invalid Chapters
 		var validCatalogs []Chapters
-		for i := 0; i < len(catalogues); i++ {
-			if catalogueErrors[i] == nil {
-				validCatalogs = append(validCatalogs, catalogues[i])
-			}
+		var validUrls []string
+		validIndexes := used
+		for _, i := range validIndexes {
+			validCatalogs = append(validCatalogs, catalogues[i])
+			validUrls = append(validUrls, urls[i])
 		}
 		// Check for Empty
 		if len(validCatalogs) == 0 {
 			return nil, []error{fmt.Errorf("No Valid Catalogues")}
-		} else {
-			catalogueErrors = make([]error, len(validCatalogs), len(validCatalogs))
 		}
 		finish := display.TemporaryText(writer, "Validating Catalogues...", signal)
-		catalogues = ValidCatalog(validCatalogs)
-		cnt = len(catalogues) // NOTICE: Update `cnt`
+		groups, preferred := ClusterCatalog(validCatalogs) // NOTICE: The same as `ValidCatalog`, but indexes are kept.
 		signal <- struct{}{}
 		<-finish
+		chosen := groups[preferred]
+		if options.Choose != nil {
+			candidates := make([]*Candidate, len(validCatalogs), len(validCatalogs))
+			for i, group := range groups {
+				for _, index := range group {
+					hostname, _ := utils.SignatureURL(validUrls[index])
+					candidates[index] = &Candidate{Url: validUrls[index], Hostname: hostname, Chapters: validCatalogs[index], Cluster: i, Preferred: i == preferred}
+				}
+			}
+			var picked []int
+			seen := make(map[int]bool) // NOTICE: The same catalogue is never merged with itself.
+			for _, index := range options.Choose(candidates) {
+				if index >= 0 && index < len(candidates) && !seen[index] {
+					seen[index] = true
+					picked = append(picked, index)
+				}
+			}
+			if len(picked) > 0 {
+				chosen = picked
+			}
+		}
+		catalogues, used, catalogueUrls = nil, nil, nil
+		for _, index := range chosen {
+			catalogues = append(catalogues, validCatalogs[index])
+			used = append(used, validIndexes[index])
+			catalogueUrls = append(catalogueUrls, validUrls[index])
+		}
+		cnt = len(catalogues) // NOTICE: Update `cnt`
+		catalogueErrors = make([]error, cnt, cnt)
+	}
+	if options.Metadata != nil {
+		for _, i := range used {
+			options.Metadata.Merge(metadatas[i])
+		}
+	}
+	// Selecting Chapters
+	if !options.Selection.Empty() {
+		var valid []int
+		var validCatalogs []Chapters
+		for i := 0; i < cnt; i++ {
+			if catalogueErrors[i] == nil {
+				valid = append(valid, i)
+				validCatalogs = append(validCatalogs, catalogues[i])
+			}
+		}
+		// NOTICE: Catalogues to be merged keep the same chapters.
+		if merge {
+			validCatalogs = SelectCatalogs(validCatalogs, options.Selection)
+		} else {
+			for i := range validCatalogs {
+				validCatalogs[i] = validCatalogs[i].Select(options.Selection)
+			}
+		}
+		selected := 0
+		for j, i := range valid {
+			catalogues[i] = validCatalogs[j]
+			selected += len(catalogues[i])
+		}
+		if selected == 0 {
+			return nil, []error{fmt.Errorf("No Chapters Selected")}
+		}
+	}
+	// Aligning Catalogues
+	var alternates map[*Chapter][]string
+	if merge && options.Fallback {
+		var validCatalogs []Chapters
+		var validUrls []string
+		for i := 0; i < cnt; i++ {
+			if catalogueErrors[i] == nil {
+				validCatalogs = append(validCatalogs, catalogues[i])
+				validUrls = append(validUrls, catalogueUrls[i])
+			}
+		}
+		if len(validCatalogs) > 0 {
+			aligned, a, report := AlignCatalog(validCatalogs)
+			printConflicts(writer, report, validUrls)
+			for i, c := range aligned {
+				c.Catalogues = sourceUrls(report.Sources[i], validUrls)
+			}
+			alternates = a
+			catalogues = []Chapters{aligned}
+			catalogueErrors = []error{nil}
+			catalogueUrls = nil // NOTICE: The aligned catalogue comes from all of them, which is recorded in chapters.
+			cnt = 1             // NOTICE: Update `cnt`
+		}
 	}
 	beginTime := time.Now()
 	var times int
+	retries := make(map[*Chapter]int) // Times of re-fetching suspicious chapters
 	for {
 		times++
 		fmt.Fprintf(writer, "%dth Turn:\n", times)
@@ -88,14 +220,15 @@ func Extract(writer io.Writer, urls []string, novelName string, validate bool, m
 			Urls = append(Urls, urls)
 			Maximums = append(Maximums, []int{len(urls), len(urls)})
 
-			hostname, _ := utils.SignatureURL(urls[i])
+			hostname, _ := utils.SignatureURL(urls[0])
 
 			Phases = append(Phases, 2)
 			Prefix = append(Prefix, []string{outputPrePostfixEachTurn + hostname + " Fetch: ", outputPrePostfixEachTurn + hostname + " Extract: "})
 			Postfix = append(Postfix, []string{outputPrePostfixEachTurn, outputPrePostfixEachTurn})
 
 			fetchSignal := make(chan struct{})
-			resultChan, extractSignal, ioCompletes := Content(urls, fetchSignal)
+			// NOTICE: Cache is bypassed after the first turn, since suspicious pages may have been cached.
+			resultChan, extractSignal, ioCompletes := Content(urls, times > 1, fetchSignal)
 			ContentSignals = append(ContentSignals, resultChan)
 			IOCompletes = append(IOCompletes, ioCompletes...)
 			Signals = append(Signals, []<-chan struct{}{fetchSignal, extractSignal})
@@ -118,12 +251,27 @@ func Extract(writer io.Writer, urls []string, novelName string, validate bool, m
 					if result.Errs[k] == nil {
 						catalogues[index][j].Fetch = true
 						catalogues[index][j].Content = result.Contents[k]
+						catalogues[index][j].Problem = ""
+						catalogues[index][j].Source, _ = utils.SignatureURL(catalogues[index][j].Url)
+						catalogues[index][j].FetchTime = time.Now()
 					} else {
 						hasFail = true
+						fallback(catalogues[index][j], alternates)
 					}
 					k++
 				}
 			}
+			// Route suspicious contents back for re-fetching.
+			for _, issue := range ValidateContents(catalogues[index]) {
+				c := catalogues[index][issue.Index]
+				c.Problem = issue.Problem
+				if retries[c] < maximumValidateRetry {
+					retries[c]++
+					c.Fetch = false
+					fallback(c, alternates)
+					hasFail = true
+				}
+			}
 		}
 		<-finish
 		fmt.Fprintf(writer, "I/O Synchronizing...\n")
@@ -138,21 +286,65 @@ func Extract(writer io.Writer, urls []string, novelName string, validate bool, m
 	}
 	fmt.Printf("After %dth Turn, finish all pages.\n", times)
 	fmt.Printf("Total time: %.0f secs.\n", time.Since(beginTime).Seconds())
+	if signal := make(chan struct{}); options.Cleaner != nil {
+		finish := display.TemporaryText(writer, "Cleaning Contents...", signal)
+		for i := 0; i < cnt; i++ {
+			if catalogueErrors[i] == nil {
+				options.Cleaner.Clean(catalogues[i])
+			}
+		}
+		signal <- struct{}{}
+		<-finish
+	}
+	scorer := options.Scorer
+	if scorer == nil {
+		scorer = DefaultScorer
+	}
+	for i := 0; i < cnt; i++ {
+		if catalogueErrors[i] != nil {
+			continue
+		}
+		for _, c := range catalogues[i] {
+			scoreChapter(c, scorer)
+			if c.Fetch {
+				c.Hash = ContentHash(c.Content)
+			}
+		}
+	}
 	if signal := make(chan struct{}); merge {
 		finish := display.TemporaryText(writer, "Merging Catalogues...", signal)
-		var mergedCatalogues Chapters
+		var validCatalogs []Chapters
+		var validUrls []string
 		for i := 0; i < len(catalogues); i++ {
 			// Recheck since validate and merge are separate.
-			if catalogueErrors[i] != nil {
-				continue
+			if catalogueErrors[i] == nil {
+				validCatalogs = append(validCatalogs, catalogues[i])
+				if i < len(catalogueUrls) {
+					validUrls = append(validUrls, catalogueUrls[i])
+				}
 			}
-			mergedCatalogues = MergeCatalog(mergedCatalogues, catalogues[i])
 		}
+		mergedCatalogues, report := MergeCatalogs(validCatalogs, scorer)
 		signal <- struct{}{}
 		<-finish
 		if mergedCatalogues == nil {
 			return nil, []error{fmt.Errorf("No Valid Catalogues after merging")}
 		} else {
+			printConflicts(writer, report, validUrls)
+			if catalogueUrls != nil {
+				for i, c := range mergedCatalogues {
+					c.Catalogues = sourceUrls(report.Sources[i], validUrls)
+				}
+			}
+			if len(report.Choices) > 0 {
+				fmt.Fprintf(writer, "NOTICE: %d chapters differ among catalogues, keeping the better scored ones.\n", len(report.Choices))
+				for _, choice := range report.Choices {
+					fmt.Fprintf(writer, outputPrePostfixEachTurn+"%s: %s\n", choice.Chosen.Name, describeScore(choice.Chosen))
+					for _, c := range choice.Others {
+						fmt.Fprintf(writer, outputPrePostfixEachTurn+outputPrePostfixEachTurn+"over %s\n", describeScore(c))
+					}
+				}
+			}
 			// Update Return Values
 			catalogues = []Chapters{mergedCatalogues}
 			catalogueErrors = []error{nil}
@@ -160,3 +352,24 @@ func Extract(writer io.Writer, urls []string, novelName string, validate bool, m
 	}
 	return catalogues, catalogueErrors
 }
+
+// sourceUrls give urls of catalogues by their indexes, skipping unknown ones.
+func sourceUrls(sources []int, urls []string) (rets []string) {
+	for _, index := range sources {
+		if index < len(urls) {
+			rets = append(rets, urls[index])
+		}
+	}
+	return
+}
+
+// printConflicts list chapters ordered differently among catalogues, with urls of catalogues disagreeing.
+func printConflicts(writer io.Writer, report *MergeReport, urls []string) {
+	if len(report.Conflicts) == 0 {
+		return
+	}
+	fmt.Fprintf(writer, "NOTICE: %d chapters are ordered differently among catalogues, following the majority.\n", len(report.Conflicts))
+	for _, conflict := range report.Conflicts {
+		fmt.Fprintf(writer, outputPrePostfixEachTurn+"%s: %s\n", conflict.Name, strings.Join(sourceUrls(conflict.Sources, urls), ", "))
+	}
+}
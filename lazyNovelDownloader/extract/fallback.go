@@ -0,0 +1,67 @@
+// fallback fetch chapters from a primary catalogue, turning to others only for failed or suspicious chapters.
+package extract
+
+// AlignCatalog merge catalogues by parsed titles before any content is fetched, in the same order as `MergeCatalogs`.
+// Chapters take urls from the primary catalogue, which has the most chapters, or the first one if tied.
+// Chapters lacking in the primary take urls from the first of others having them. Urls from the rest are alternates in order.
+// @return alternates map[*Chapter][]string urls of the same chapter in other catalogues.
+// @return r *MergeReport where Sources of each chapter begin with the catalogue giving its url.
+func AlignCatalog(c_s []Chapters) (merged Chapters, alternates map[*Chapter][]string, r *MergeReport) {
+	alternates = make(map[*Chapter][]string)
+	r = &MergeReport{}
+	keys := make([][]string, len(c_s), len(c_s))
+	keyMaps := make([]map[string]*Chapter, len(c_s), len(c_s))
+	primary := 0
+	for i, c := range c_s {
+		keys[i] = c.Keys()
+		keyMaps[i] = make(map[string]*Chapter)
+		for j, chapter := range c {
+			keyMaps[i][keys[i][j]] = chapter
+		}
+		if len(keyMaps[i]) > len(keyMaps[primary]) {
+			primary = i
+		}
+	}
+	// NOTICE: The primary is tried first, and the others keep their order.
+	indexes := []int{primary}
+	for i := range c_s {
+		if i != primary {
+			indexes = append(indexes, i)
+		}
+	}
+	order, conflicts := consensusOrder(keys)
+	for _, key := range order {
+		var chapter *Chapter
+		var source []int
+		for _, i := range indexes {
+			c, ok := keyMaps[i][key]
+			if !ok {
+				continue
+			}
+			source = append(source, i)
+			if chapter == nil {
+				chapter = &Chapter{Name: c.Name, Url: c.Url}
+			} else {
+				alternates[chapter] = append(alternates[chapter], c.Url)
+			}
+		}
+		merged = append(merged, chapter)
+		r.Sources = append(r.Sources, source)
+		if s, ok := conflicts[key]; ok {
+			r.Conflicts = append(r.Conflicts, Conflict{Name: chapter.Name, Sources: s})
+		}
+	}
+	return
+}
+
+// fallback switch chapter to its next alternate url, and put the current one at the end.
+// false is returned if chapter has no alternate.
+func fallback(c *Chapter, alternates map[*Chapter][]string) bool {
+	urls := alternates[c]
+	if len(urls) == 0 {
+		return false
+	}
+	alternates[c] = append(urls[1:], c.Url)
+	c.Url = urls[0]
+	return true
+}
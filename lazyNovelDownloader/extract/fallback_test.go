@@ -0,0 +1,77 @@
+package extract_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func TestAlignCatalog(t *testing.T) {
+	u := extract.Chapters{
+		&extract.Chapter{Name: "第一章 绯红", Url: "u/1"},
+		&extract.Chapter{Name: "第三章 笔记", Url: "u/3"},
+	}
+	v := extract.Chapters{
+		&extract.Chapter{Name: "1.绯红", Url: "v/1"},
+		&extract.Chapter{Name: "2.情况", Url: "v/2"},
+		&extract.Chapter{Name: "3.笔记", Url: "v/3"},
+	}
+	// v is the primary, since it has the most chapters.
+	merged, alternates, r := extract.AlignCatalog([]extract.Chapters{u, v})
+	expects := []string{"v/1", "v/2", "v/3"}
+	if len(merged) != len(expects) {
+		t.Fatalf("Get %v. Expect %v.\n", merged, expects)
+	}
+	for i := range merged {
+		if merged[i].Url != expects[i] {
+			t.Errorf("Get %v. Expect %v.\n", merged[i].Url, expects[i])
+		}
+	}
+	if urls := alternates[merged[0]]; len(urls) != 1 || urls[0] != "u/1" {
+		t.Errorf("Get %v. Expect %v.\n", urls, []string{"u/1"})
+	}
+	if urls := alternates[merged[1]]; len(urls) != 0 {
+		t.Errorf("Get %v. Expect %v.\n", urls, []string{})
+	}
+	if s := r.Sources[0]; !reflect.DeepEqual(s, []int{1, 0}) {
+		t.Errorf("Get %v. Expect %v.\n", s, []int{1, 0})
+	}
+}
+
+func TestAlignCatalogGaps(t *testing.T) {
+	u := extract.Chapters{
+		&extract.Chapter{Name: "第一章 绯红", Url: "u/1"},
+		&extract.Chapter{Name: "第二章 情况", Url: "u/2"},
+		&extract.Chapter{Name: "第四章 占卜", Url: "u/4"},
+	}
+	v := extract.Chapters{
+		&extract.Chapter{Name: "2.情况", Url: "v/2"},
+		&extract.Chapter{Name: "3.笔记", Url: "v/3"},
+	}
+	w := extract.Chapters{
+		&extract.Chapter{Name: "3 笔记", Url: "w/3"},
+	}
+	// u is the primary, and the gap of the third chapter is filled by v, then w.
+	merged, alternates, r := extract.AlignCatalog([]extract.Chapters{v, u, w})
+	type Data struct {
+		url        string
+		alternates []string
+		sources    []int
+	}
+	expects := map[string]Data{
+		"第一章 绯红": Data{"u/1", nil, []int{1}},
+		"第二章 情况": Data{"u/2", []string{"v/2"}, []int{1, 0}},
+		"3.笔记":   Data{"v/3", []string{"w/3"}, []int{0, 2}},
+		"第四章 占卜": Data{"u/4", nil, []int{1}},
+	}
+	if len(merged) != len(expects) {
+		t.Fatalf("Get %v. Expect %v.\n", merged, expects)
+	}
+	for i, c := range merged {
+		d := expects[c.Name]
+		if c.Url != d.url || !reflect.DeepEqual(alternates[c], d.alternates) || !reflect.DeepEqual(r.Sources[i], d.sources) {
+			t.Errorf("Get %v, %v, %v from %v. Expect %v.\n", c.Url, alternates[c], r.Sources[i], c.Name, d)
+		}
+	}
+}
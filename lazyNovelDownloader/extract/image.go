@@ -0,0 +1,122 @@
+// image keep <img> of contents as placeholders, e.g. `[Image: https://example.com/1.jpg]`, which writers turn into images or links.
+package extract
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+	"golang.org/x/net/html"
+)
+
+const (
+	imagePrefix = "[Image: "
+	imageSuffix = "]"
+	// Markers stand for images while extracting text, since urls in text are removed by `formatString`.
+	imageMarkerStart = "\uE000"
+	imageMarkerEnd   = "\uE001"
+)
+
+var (
+	imagePattern       = regexp.MustCompile(`\[Image: ([^\s\]]+)\]`)
+	imageMarkerPattern = regexp.MustCompile(imageMarkerStart + `(\d+)` + imageMarkerEnd)
+	// imageSourceAttrs are attributes holding url of image, where the former ones are for lazy loading.
+	imageSourceAttrs = []string{"data-original", "data-src", "src"}
+	// imageURLEscaper keep placeholder in one piece.
+	imageURLEscaper = strings.NewReplacer(" ", "%20", "]", "%5D")
+)
+
+// ImageText give the placeholder of image in url.
+func ImageText(url string) string {
+	return imagePrefix + imageURLEscaper.Replace(url) + imageSuffix
+}
+
+// Images give urls of images in content, in the order they appear.
+func Images(content string) (urls []string) {
+	for _, m := range imagePattern.FindAllStringSubmatch(content, -1) {
+		urls = append(urls, m[1])
+	}
+	return
+}
+
+// ReplaceImages replace every placeholder in content by the return of replace given its url.
+func ReplaceImages(content string, replace func(url string) string) string {
+	return imagePattern.ReplaceAllStringFunc(content, func(s string) string {
+		return replace(imagePattern.FindStringSubmatch(s)[1])
+	})
+}
+
+// withoutImages remove placeholders from content, so that only texts are measured, e.g. by `ValidateContents` and `WeightedScorer`.
+func withoutImages(content string) string {
+	return imagePattern.ReplaceAllString(content, "")
+}
+
+// MaskImages apply f to content with placeholders masked, so that urls of images are never changed by f,
+// e.g. conversion between Simplified and Traditional Chinese, or normalization of typography.
+func MaskImages(content string, f func(string) string) string {
+	urls := Images(content)
+	i := 0
+	masked := imagePattern.ReplaceAllStringFunc(content, func(string) string {
+		i++
+		return imageMarker(i - 1)
+	})
+	return unmarkImages(f(masked), urls)
+}
+
+func imageMarker(i int) string {
+	return imageMarkerStart + strconv.Itoa(i) + imageMarkerEnd
+}
+
+// exceptImages apply f to parts of line outside placeholders, so that urls of images are never cleaned.
+func exceptImages(line string, f func(string) string) (ret string) {
+	last := 0
+	for _, loc := range imagePattern.FindAllStringIndex(line, -1) {
+		ret += f(line[last:loc[0]]) + line[loc[0]:loc[1]]
+		last = loc[1]
+	}
+	return ret + f(line[last:])
+}
+
+// markImages put a marker in front of every <img> under root, and return their urls completed by pageURL.
+// NOTICE: Markers are put outside <a>, whose texts are omitted as links, e.g. `<a href="1.jpg"><img src="1s.jpg"></a>`.
+func markImages(root *html.Node, pageURL string) (urls []string) {
+	for _, img := range utils.Select(root, "img") {
+		var src string
+		for _, attr := range imageSourceAttrs {
+			if src = strings.TrimSpace(attrValue(img, attr)); src != "" {
+				break
+			}
+		}
+		if src == "" || strings.HasPrefix(src, "data:") {
+			continue
+		}
+		url, err := utils.CompleteURL(pageURL, src)
+		if err != nil {
+			continue
+		}
+		target := img
+		for n := img.Parent; n != nil; n = n.Parent {
+			if n.Type == html.ElementNode && n.Data == "a" {
+				target = n
+			}
+		}
+		if target.Parent == nil {
+			continue
+		}
+		target.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: imageMarker(len(urls))}, target)
+		urls = append(urls, url)
+	}
+	return
+}
+
+// unmarkImages turn markers in content into placeholders of urls.
+func unmarkImages(content string, urls []string) string {
+	return imageMarkerPattern.ReplaceAllStringFunc(content, func(s string) string {
+		i, _ := strconv.Atoi(imageMarkerPattern.FindStringSubmatch(s)[1])
+		if i >= len(urls) {
+			return ""
+		}
+		return ImageText(urls[i])
+	})
+}
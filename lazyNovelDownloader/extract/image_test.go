@@ -0,0 +1,60 @@
+package extract_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func TestImages(t *testing.T) {
+	content := "    插图如下：\n    " + extract.ImageText("https://example.com/1.jpg") + "\n    他写下了" + extract.ImageText("https://example.com/a b].png") + "字。\n"
+	expect := []string{"https://example.com/1.jpg", "https://example.com/a%20b%5D.png"}
+	if urls := extract.Images(content); !reflect.DeepEqual(urls, expect) {
+		t.Errorf("Get %v from %v. Expect %v.\n", urls, content, expect)
+	}
+	replaced := extract.ReplaceImages(content, func(url string) string { return "<" + url + ">" })
+	if want := "    插图如下：\n    <https://example.com/1.jpg>\n    他写下了<https://example.com/a%20b%5D.png>字。\n"; replaced != want {
+		t.Errorf("Get %v from %v. Expect %v.\n", replaced, content, want)
+	}
+}
+
+func TestCleanImages(t *testing.T) {
+	type Data struct {
+		content string
+		expect  string
+	}
+	data := []Data{
+		Data{"    " + extract.ImageText("https://www.example.com/1.jpg") + "\n", "    " + extract.ImageText("https://www.example.com/1.jpg") + "\n"},
+		Data{"    www.example.com" + extract.ImageText("https://www.example.com/1.jpg") + "\n", "    " + extract.ImageText("https://www.example.com/1.jpg") + "\n"},
+	}
+	c := extract.NewCleaner()
+	for _, d := range data {
+		if ret := c.CleanText(d.content); ret != d.expect {
+			t.Errorf("Get %v from %v. Expect %v.\n", ret, d.content, d.expect)
+		}
+	}
+}
+
+func TestParseContentImages(t *testing.T) {
+	raw, err := ioutil.ReadFile(filepath.Join("testdata", "content", "images.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := extract.ParseContent(string(raw), "https://www.example.com/book/3.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"https://www.example.com/images/1.jpg", "https://www.example.com/book/glyph/42.png", "https://cdn.example.org/2.png"}
+	if urls := extract.Images(content); !reflect.DeepEqual(urls, expect) {
+		t.Errorf("Get %v from %q. Expect %v.\n", urls, content, expect)
+	}
+	for _, s := range []string{"    " + extract.ImageText(expect[0]) + "\n", "上面写着" + extract.ImageText(expect[1]) + "字", "大口喘息"} {
+		if !strings.Contains(content, s) {
+			t.Errorf("Get %q. Expect including %q.\n", content, s)
+		}
+	}
+}
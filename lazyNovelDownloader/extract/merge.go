@@ -0,0 +1,163 @@
+// merge merge all catalogues at once, so that the result does not depend on the order of sources.
+package extract
+
+import "github.com/RaymondJiangkw/Lazy/utils"
+
+// Conflict is a chapter placed elsewhere by some catalogues.
+type Conflict struct {
+	Name string
+	// Sources are indexes of catalogues disagreeing with the consensus.
+	Sources []int
+}
+
+// Choice is a chapter whose contents differ among catalogues, and the better one is chosen by score.
+type Choice struct {
+	Chosen *Chapter
+	// Others are contents of the same chapter not chosen.
+	Others Chapters
+}
+
+// MergeReport record how `MergeCatalogs` merges catalogues.
+type MergeReport struct {
+	// Sources are indexes of catalogues having each merged chapter.
+	Sources   [][]int
+	Conflicts []Conflict
+	Choices   []Choice
+}
+
+// consensusOrder build the order of keys agreed by most sources.
+// Keys in more than half of sources are anchors, and they follow the source whose anchors are the closest to all others.
+// Other keys are then inserted around anchors, source by source.
+// @return conflicts map[string][]int indexes of sources placing anchors elsewhere.
+func consensusOrder(keys [][]string) (order []string, conflicts map[string][]int) {
+	conflicts = make(map[string][]int)
+	if len(keys) == 0 {
+		return nil, conflicts
+	}
+	counts := make(map[string]int)
+	for _, k := range keys {
+		for _, key := range k {
+			counts[key]++
+		}
+	}
+	anchors := make([][]string, len(keys), len(keys))
+	for i, k := range keys {
+		for _, key := range k {
+			if counts[key] > len(keys)/2 {
+				anchors[i] = append(anchors[i], key)
+			}
+		}
+	}
+	// Majority Vote: the medoid of anchors
+	pivot, minimum := 0, -1
+	for i := range anchors {
+		sum := 0
+		for j := range anchors {
+			if i != j {
+				sum += utils.EditDistance(anchors[i], anchors[j], -1)
+			}
+		}
+		if minimum == -1 || sum < minimum {
+			pivot, minimum = i, sum
+		}
+	}
+	order = append(order, anchors[pivot]...)
+	for j := range anchors {
+		if j == pivot {
+			continue
+		}
+		has := make(map[string]bool, len(anchors[j]))
+		for _, key := range anchors[j] {
+			has[key] = true
+		}
+		for _, h := range utils.Diff(order, anchors[j]) {
+			if h.Kind != utils.EditDelete {
+				continue
+			}
+			for _, key := range order[h.SrcStart:h.SrcEnd] {
+				if has[key] { // NOTICE: It is placed elsewhere rather than missing.
+					conflicts[key] = append(conflicts[key], j)
+				}
+			}
+		}
+	}
+	// Insert the rest, starting from the pivot.
+	in := make(map[string]bool, len(order))
+	for _, key := range order {
+		in[key] = true
+	}
+	for n := 0; n < len(keys); n++ {
+		i := (pivot + n) % len(keys)
+		var merged []string
+		for _, h := range utils.Diff(order, keys[i]) {
+			if h.Kind != utils.EditInsert {
+				merged = append(merged, order[h.SrcStart:h.SrcEnd]...)
+				continue
+			}
+			for _, key := range keys[i][h.DstStart:h.DstEnd] {
+				if !in[key] {
+					merged = append(merged, key)
+					in[key] = true
+				}
+			}
+		}
+		order = merged
+	}
+	return
+}
+
+// MergeCatalogs merge catalogues by the consensus of their orders, matching chapters by parsed titles.
+// The better content of the same chapter is chosen by scorer. See `MergeCatalogBy`.
+// @param scorer QualityScorer (default: DefaultScorer)
+func MergeCatalogs(c_s []Chapters, scorer QualityScorer) (rets Chapters, r *MergeReport) {
+	if scorer == nil {
+		scorer = DefaultScorer
+	}
+	r = &MergeReport{}
+	keys := make([][]string, len(c_s), len(c_s))
+	keyMaps := make([]map[string]*Chapter, len(c_s), len(c_s))
+	for i, c := range c_s {
+		keys[i] = c.Keys()
+		keyMaps[i] = make(map[string]*Chapter, len(c))
+		for j, chapter := range c {
+			keyMaps[i][keys[i][j]] = chapter
+		}
+	}
+	order, conflicts := consensusOrder(keys)
+	for _, key := range order {
+		var best *Chapter
+		var sources []int
+		var candidates Chapters
+		for i, keyMap := range keyMaps {
+			c, ok := keyMap[key]
+			if !ok {
+				continue
+			}
+			sources = append(sources, i)
+			candidates = append(candidates, c)
+			if best == nil {
+				best = c
+			} else {
+				best = contentQualityOver(best, c, scorer)
+			}
+		}
+		rets = append(rets, best)
+		// NOTICE: Only different contents fetched are choices worth reporting.
+		var others Chapters
+		differ := false
+		for _, c := range candidates {
+			if c != best && c.Fetch {
+				others = append(others, c)
+				differ = differ || c.Hash != best.Hash
+			}
+		}
+		if best.Fetch && differ {
+			r.Choices = append(r.Choices, Choice{Chosen: best, Others: others})
+		}
+		r.Sources = append(r.Sources, sources)
+		if s, ok := conflicts[key]; ok {
+			r.Conflicts = append(r.Conflicts, Conflict{Name: best.Name, Sources: s})
+		}
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package extract_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func catalogue(names ...string) (rets extract.Chapters) {
+	for _, name := range names {
+		rets = append(rets, &extract.Chapter{Name: name})
+	}
+	return
+}
+
+func TestMergeCatalogs(t *testing.T) {
+	c_s := []extract.Chapters{
+		// Out of order, which should not be followed.
+		catalogue("序章", "第二章 B", "第一章 A", "第三章 C", "第四章 D"),
+		catalogue("序章", "1.A", "2.B", "3.C", "请假条", "4.D"),
+		catalogue("序章", "第1章 A", "第2章 B", "第3章 C", "第4章 D", "第5章 E"),
+	}
+	merged, r := extract.MergeCatalogs(c_s, nil)
+	// Names come from the first catalogue having them, since no content is fetched.
+	expects := []string{"序章", "第一章 A", "第二章 B", "第三章 C", "请假条", "第四章 D", "第5章 E"}
+	if len(merged) != len(expects) {
+		t.Fatalf("Get %v. Expect %v.\n", names(merged), expects)
+	}
+	for i := range merged {
+		if merged[i].Name != expects[i] {
+			t.Fatalf("Get %v. Expect %v.\n", names(merged), expects)
+		}
+	}
+	if len(r.Conflicts) != 1 || len(r.Conflicts[0].Sources) != 1 || r.Conflicts[0].Sources[0] != 0 {
+		t.Errorf("Get %v. Expect one conflict from catalogue 0.\n", r.Conflicts)
+	}
+	if s := r.Sources[4]; len(s) != 1 || s[0] != 1 {
+		t.Errorf("Get %v. Expect %v.\n", s, []int{1})
+	}
+	if s := r.Sources[0]; len(s) != 3 {
+		t.Errorf("Get %v. Expect %v.\n", s, []int{0, 1, 2})
+	}
+}
+
+func TestMergeCatalogsChoices(t *testing.T) {
+	good := strings.Repeat("    克莱恩推开门，走进了冰冷的夜色中，街道两侧的煤气路灯散发着昏黄的光芒。\n", 6)
+	bad := strings.Replace(good, "夜色", "锟斤拷", -1)
+	var fetched = func(source, content string) *extract.Chapter {
+		return &extract.Chapter{Name: "第一章 绯红", Content: content, Fetch: true, Source: source, Hash: extract.ContentHash(content)}
+	}
+	c_s := []extract.Chapters{
+		extract.Chapters{fetched("a.com", bad), fetched("a.com", good)},
+		extract.Chapters{fetched("b.com", good), fetched("b.com", good)},
+	}
+	c_s[0][1].Name, c_s[1][1].Name = "第二章 情况", "第二章 情况"
+	merged, r := extract.MergeCatalogs(c_s, nil)
+	if len(merged) != 2 || merged[0].Source != "b.com" {
+		t.Fatalf("Get %v. Expect content of b.com first.\n", merged)
+	}
+	// Chapters of the same content are no choice.
+	if len(r.Choices) != 1 || r.Choices[0].Chosen != merged[0] || len(r.Choices[0].Others) != 1 || r.Choices[0].Others[0].Source != "a.com" {
+		t.Fatalf("Get %v. Expect b.com chosen over a.com.\n", r.Choices)
+	}
+	for _, c := range []*extract.Chapter{r.Choices[0].Chosen, r.Choices[0].Others[0]} {
+		if c.Quality == nil || c.Score == 0 {
+			t.Errorf("Get %v, %v from %v. Expect the breakdown of score.\n", c.Score, c.Quality, c.Source)
+		}
+	}
+	if q := r.Choices[0].Others[0].Quality; q != nil && q.Garbled >= r.Choices[0].Chosen.Quality.Garbled {
+		t.Errorf("Get %v from a.com. Expect more garbled than %v.\n", q, r.Choices[0].Chosen.Quality)
+	}
+}
+
+// countingScorer count chapters scored, giving every one the same score.
+type countingScorer struct {
+	count int
+}
+
+func (s *countingScorer) Score(c *extract.Chapter) float64 {
+	s.count++
+	return 0.5
+}
+
+func TestMergeCatalogsStoredScores(t *testing.T) {
+	var fetched = func(source string, score float64) *extract.Chapter {
+		content := "    克莱恩推开门，走进了冰冷的夜色中。\n"
+		return &extract.Chapter{Name: "第一章 绯红", Content: content, Fetch: true, Source: source, Score: score, Hash: extract.ContentHash(content + source)}
+	}
+	scorer := &countingScorer{}
+	merged, _ := extract.MergeCatalogs([]extract.Chapters{extract.Chapters{fetched("a.com", 0.2)}, extract.Chapters{fetched("b.com", 0.9)}}, scorer)
+	if len(merged) != 1 || merged[0].Source != "b.com" || scorer.count != 0 {
+		t.Errorf("Get %v with %v chapters scored. Expect b.com without scoring.\n", merged, scorer.count)
+	}
+	// Chapters not scored yet are scored.
+	merged, _ = extract.MergeCatalogs([]extract.Chapters{extract.Chapters{fetched("a.com", 0)}, extract.Chapters{fetched("b.com", 0)}}, scorer)
+	if len(merged) != 1 || merged[0].Score != 0.5 || scorer.count != 2 {
+		t.Errorf("Get %v with %v chapters scored. Expect both scored.\n", merged, scorer.count)
+	}
+}
@@ -0,0 +1,100 @@
+// metadata scrape information of novel from catalogue pages, e.g. cover and synopsis in `og:` meta tags.
+package extract
+
+import (
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+	"golang.org/x/net/html"
+)
+
+const (
+	StatusOngoing   = "ongoing"
+	StatusCompleted = "completed"
+)
+
+var (
+	// tagSeparators split `og:novel:tags`.
+	tagSeparators = ",，、|/ "
+	// statusWords tell status by `og:novel:status`, e.g. `连载中`, `已完结`, `完本`.
+	statusWords = map[string][]string{
+		StatusOngoing:   []string{"连载", "連載", "未完", "更新中", "ongoing", "serial"},
+		StatusCompleted: []string{"完结", "完結", "完本", "已完成", "全本", "completed", "finished"},
+	}
+)
+
+// Metadata is the information of novel given by a catalogue page.
+type Metadata struct {
+	Name     string
+	Author   string
+	Cover    string // URL of cover image
+	Synopsis string
+	Genre    string
+	Tags     []string
+	Status   string // StatusOngoing, StatusCompleted, or as it is on the page if unknown.
+	Source   string // URL of catalogue
+}
+
+// firstMeta return the first non-empty value of keys.
+func firstMeta(metas map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v := metas[key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// normalizeStatus turn status on the page into StatusOngoing or StatusCompleted if possible.
+func normalizeStatus(status string) string {
+	lower := strings.ToLower(status)
+	for _, s := range []string{StatusOngoing, StatusCompleted} { // NOTICE: `未完结` is ongoing.
+		for _, word := range statusWords[s] {
+			if strings.Contains(lower, word) {
+				return s
+			}
+		}
+	}
+	return status
+}
+
+// ParseMetadata scrape metadata from `og:` meta tags of catalogue page in url, e.g. `og:image`, `og:description`,
+// `og:novel:author`, `og:novel:category`, `og:novel:status`.
+func ParseMetadata(doc *html.Node, url string) *Metadata {
+	metas := utils.ParseMetaTags(doc)
+	m := &Metadata{
+		Name:     firstMeta(metas, "og:novel:book_name", "og:title"),
+		Author:   firstMeta(metas, "og:novel:author"),
+		Synopsis: strings.Join(strings.Fields(firstMeta(metas, "og:description")), " "),
+		Genre:    firstMeta(metas, "og:novel:category", "og:novel:genre"),
+		Status:   normalizeStatus(firstMeta(metas, "og:novel:status")),
+		Source:   url,
+	}
+	if cover := firstMeta(metas, "og:image"); cover != "" {
+		m.Cover, _ = utils.CompleteURL(url, cover)
+	}
+	for _, tag := range strings.FieldsFunc(firstMeta(metas, "og:novel:tags", "og:novel:tag"), func(r rune) bool {
+		return strings.ContainsRune(tagSeparators, r)
+	}) {
+		m.Tags = append(m.Tags, tag)
+	}
+	return m
+}
+
+// Merge fill empty fields of m by o.
+func (m *Metadata) Merge(o *Metadata) {
+	if o == nil {
+		return
+	}
+	for _, f := range []struct{ dst, src *string }{
+		{&m.Name, &o.Name}, {&m.Author, &o.Author}, {&m.Cover, &o.Cover}, {&m.Synopsis, &o.Synopsis},
+		{&m.Genre, &o.Genre}, {&m.Status, &o.Status}, {&m.Source, &o.Source},
+	} {
+		if *f.dst == "" {
+			*f.dst = *f.src
+		}
+	}
+	if len(m.Tags) == 0 {
+		m.Tags = append([]string(nil), o.Tags...)
+	}
+}
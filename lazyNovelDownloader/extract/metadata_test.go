@@ -0,0 +1,62 @@
+package extract_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"golang.org/x/net/html"
+)
+
+func TestParseMetadata(t *testing.T) {
+	page := `<html><head>
+<meta property="og:title" content="诡秘之主最新章节">
+<meta property="og:novel:book_name" content="诡秘之主">
+<meta property="og:novel:author" content="爱潜水的乌贼">
+<meta property="og:description" content="  蒸汽与机械的浪潮中，
+    谁能触及非凡？ ">
+<meta property="og:image" content="/cover/1.jpg">
+<meta property="og:novel:category" content="玄幻">
+<meta property="og:novel:status" content="已完结">
+<meta property="og:novel:tags" content="克苏鲁，蒸汽朋克">
+</head><body></body></html>`
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := extract.ParseMetadata(doc, "https://example.com/book/1/")
+	expect := &extract.Metadata{
+		Name:     "诡秘之主",
+		Author:   "爱潜水的乌贼",
+		Cover:    "https://example.com/cover/1.jpg",
+		Synopsis: "蒸汽与机械的浪潮中， 谁能触及非凡？",
+		Genre:    "玄幻",
+		Tags:     []string{"克苏鲁", "蒸汽朋克"},
+		Status:   extract.StatusCompleted,
+		Source:   "https://example.com/book/1/",
+	}
+	if !reflect.DeepEqual(m, expect) {
+		t.Errorf("Get %v. Expect %v.\n", *m, *expect)
+	}
+}
+
+func TestMetadataStatus(t *testing.T) {
+	type Data struct {
+		status string
+		expect string
+	}
+	data := []Data{
+		Data{"连载中", extract.StatusOngoing},
+		Data{"未完结", extract.StatusOngoing},
+		Data{"完本", extract.StatusCompleted},
+		Data{"Completed", extract.StatusCompleted},
+		Data{"暂停", "暂停"},
+	}
+	for _, d := range data {
+		doc, _ := html.Parse(strings.NewReader(`<meta property="og:novel:status" content="` + d.status + `">`))
+		if m := extract.ParseMetadata(doc, ""); m.Status != d.expect {
+			t.Errorf("Get %v from %v. Expect %v.\n", m.Status, d.status, d.expect)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// order repair the order of chapters by parsed numbers, and find missing ones.
+package extract
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	minimumNumberedRatio = 0.5 // below which catalogue is left as it is
+	maximumOutlierStep   = 2   // neighbors of an outlier are consecutive within the step
+	minimumOutlierOffset = 10  // and the outlier is far from both of them
+	maximumRestartNumber = 3   // above which a number dropping back is not perceived as restart
+)
+
+// Gap is a range of missing chapter numbers, inclusive.
+type Gap struct {
+	Volume int // 0 means numbers do not restart in volumes.
+	From   int
+	To     int
+}
+
+func (g Gap) String() (ret string) {
+	if g.Volume > 0 {
+		ret = fmt.Sprintf("volume %d ", g.Volume)
+	}
+	if g.From == g.To {
+		return ret + fmt.Sprintf("chapter %d missing", g.From)
+	}
+	return ret + fmt.Sprintf("chapters %d–%d missing", g.From, g.To)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// duplicatedChapters tells whether every chapter appears again later with the same name and url,
+// e.g. up-to-date chapters listed at the top of catalogue.
+func duplicatedChapters(c Chapters) []bool {
+	rets := make([]bool, len(c), len(c))
+	type identity struct {
+		name, url string
+	}
+	appears := make(map[identity]bool)
+	for i := len(c) - 1; i >= 0; i-- {
+		id := identity{normalizeText(c[i].Name), c[i].Url}
+		rets[i] = appears[id]
+		appears[id] = true
+	}
+	return rets
+}
+
+// numberTitles parse titles of chapters for ordering, where duplicated ones are skipped when finding volumes and outliers.
+// Volume is kept only if numbers restart in volumes, and chapters without volume inherit the previous one.
+// If titles carry no volume, numbers are perceived to restart when a number no more than `maximumRestartNumber`
+// drops back and appears again, e.g. `第1章` after `第1章`...`第30章`, which is the same as `@n` of `Keys`.
+// Number of outlier, e.g. `第1200章` between `第119章` and `第121章`, is dropped.
+func numberTitles(c Chapters, duplicates []bool) []Title {
+	titles := make([]Title, len(c), len(c))
+	volumes := make(map[int]int)
+	restart := false
+	for i, chapter := range c {
+		titles[i] = ParseTitle(chapter.Name)
+		if t := titles[i]; t.Number > 0 && t.Volume > 0 && !duplicates[i] {
+			if v, ok := volumes[t.Number]; ok && v != t.Volume {
+				restart = true
+			}
+			volumes[t.Number] = t.Volume
+		}
+	}
+	volume := 0
+	for i := range titles {
+		if !restart {
+			titles[i].Volume = 0
+		} else if titles[i].Volume == 0 {
+			titles[i].Volume = volume
+		}
+		volume = titles[i].Volume
+	}
+	if !restart {
+		inferVolumes(titles, duplicates)
+	}
+	var numbered []int
+	for i := range titles {
+		if titles[i].Number > 0 && !duplicates[i] {
+			numbered = append(numbered, i)
+		}
+	}
+	var outliers []int
+	for k := 1; k+1 < len(numbered); k++ {
+		p, t, n := titles[numbered[k-1]], titles[numbered[k]], titles[numbered[k+1]]
+		if p.Volume != n.Volume || p.Volume != t.Volume {
+			continue
+		}
+		if step := n.Number - p.Number; step >= 1 && step <= maximumOutlierStep && abs(t.Number-p.Number) >= minimumOutlierOffset && abs(t.Number-n.Number) >= minimumOutlierOffset {
+			outliers = append(outliers, numbered[k])
+		}
+	}
+	for _, i := range outliers {
+		titles[i].Number = 0
+	}
+	return titles
+}
+
+// inferVolumes number volumes of titles by restarts of numbers, if there is any. See `numberTitles`.
+func inferVolumes(titles []Title, duplicates []bool) {
+	volumes := make([]int, len(titles), len(titles))
+	appears := make(map[int]bool)
+	volume, prev, restart := 1, 0, false
+	for i, t := range titles {
+		if t.Number > 0 && !duplicates[i] {
+			if t.Number <= maximumRestartNumber && t.Number < prev && appears[t.Number] {
+				volume++
+				restart = true
+				appears = make(map[int]bool)
+			}
+			appears[t.Number] = true
+			prev = t.Number
+		}
+		volumes[i] = volume
+	}
+	if !restart {
+		return
+	}
+	for i := range titles {
+		titles[i].Volume = volumes[i]
+	}
+}
+
+// OrderReport record how `RepairOrder` changes a catalogue.
+type OrderReport struct {
+	// Moved is the number of chapters not in their original positions.
+	Moved int
+	// Duplicates are chapters removed since the same name and url appear later.
+	Duplicates Chapters
+}
+
+// RepairOrder sort chapters by parsed numbers and remove duplicated chapters, keeping the last appearance.
+// Chapters with the same number but different names or urls are all kept, e.g. those of different volumes.
+// Chapters without numbers, e.g. `上架感言`, stay after the chapter preceding them.
+// Catalogue is left as it is, if most chapters have no number.
+func RepairOrder(c Chapters) (rets Chapters, r *OrderReport) {
+	r = &OrderReport{}
+	duplicates := duplicatedChapters(c)
+	titles := numberTitles(c, duplicates)
+	numbered := 0
+	for _, t := range titles {
+		if t.Number > 0 {
+			numbered++
+		}
+	}
+	if len(c) == 0 || float64(numbered) < float64(len(c))*minimumNumberedRatio {
+		return c, r
+	}
+	type position struct {
+		volume, number, sub int
+	}
+	var kept Chapters
+	var positions []position
+	anchor := position{}
+	for i, t := range titles {
+		if duplicates[i] {
+			r.Duplicates = append(r.Duplicates, c[i])
+			continue
+		}
+		if t.Number == 0 {
+			anchor.sub++
+			kept = append(kept, c[i])
+			positions = append(positions, anchor)
+			continue
+		}
+		p := position{t.Volume, t.Number, 0}
+		anchor = p
+		kept = append(kept, c[i])
+		positions = append(positions, p)
+	}
+	order := make([]int, len(kept), len(kept))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		u, v := positions[order[i]], positions[order[j]]
+		if u.volume != v.volume {
+			return u.volume < v.volume
+		}
+		if u.number != v.number {
+			return u.number < v.number
+		}
+		return u.sub < v.sub
+	})
+	rets = make(Chapters, len(kept), len(kept))
+	for i, index := range order {
+		rets[i] = kept[index]
+		if index != i {
+			r.Moved++
+		}
+	}
+	return
+}
+
+// Gaps find missing chapter numbers between consecutive numbered chapters.
+// Missing chapters before the first one are not reported, since catalogue may start anywhere.
+func (c Chapters) Gaps() (rets []Gap) {
+	prev := Title{}
+	duplicates := duplicatedChapters(c)
+	for i, t := range numberTitles(c, duplicates) {
+		if t.Number == 0 || duplicates[i] {
+			continue
+		}
+		if prev.Number > 0 && t.Volume == prev.Volume && t.Number > prev.Number+1 {
+			rets = append(rets, Gap{Volume: t.Volume, From: prev.Number + 1, To: t.Number - 1})
+		}
+		prev = t
+	}
+	return
+}
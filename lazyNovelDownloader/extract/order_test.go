@@ -0,0 +1,111 @@
+package extract_test
+
+import (
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func names(c extract.Chapters) (rets []string) {
+	for _, chapter := range c {
+		rets = append(rets, chapter.Name)
+	}
+	return
+}
+
+func TestRepairOrder(t *testing.T) {
+	type Data struct {
+		names      []string
+		expect     []string
+		duplicates int
+	}
+	data := []Data{
+		// Up-to-date chapters at the top
+		Data{[]string{"第5章 E", "第4章 D", "第1章 A", "第2章 B", "第3章 C", "第4章 D", "第5章 E"}, []string{"第1章 A", "第2章 B", "第3章 C", "第4章 D", "第5章 E"}, 2},
+		// Swapped chapters, and notes staying after preceding chapters
+		Data{[]string{"第1章 A", "第3章 C", "第2章 B", "请假条", "第4章 D"}, []string{"第1章 A", "第2章 B", "请假条", "第3章 C", "第4章 D"}, 0},
+		// Outlier caused by typo
+		Data{[]string{"第119章 A", "第1200章 B", "第121章 C"}, []string{"第119章 A", "第1200章 B", "第121章 C"}, 0},
+		// Numbers restart in volumes
+		Data{[]string{"第一卷 第2章 B", "第一卷 第1章 A", "第二卷 第1章 C"}, []string{"第一卷 第1章 A", "第一卷 第2章 B", "第二卷 第1章 C"}, 0},
+		// Most chapters have no number
+		Data{[]string{"B", "A", "第2章", "C"}, []string{"B", "A", "第2章", "C"}, 0},
+	}
+	for _, d := range data {
+		var c extract.Chapters
+		for _, name := range d.names {
+			c = append(c, &extract.Chapter{Name: name})
+		}
+		rets, r := extract.RepairOrder(c)
+		if got := names(rets); len(got) != len(d.expect) {
+			t.Errorf("Get %v from %v. Expect %v.\n", got, d.names, d.expect)
+		} else {
+			for i := range got {
+				if got[i] != d.expect[i] {
+					t.Errorf("Get %v from %v. Expect %v.\n", got, d.names, d.expect)
+					break
+				}
+			}
+		}
+		if len(r.Duplicates) != d.duplicates {
+			t.Errorf("Get %v duplicates from %v. Expect %v.\n", len(r.Duplicates), d.names, d.duplicates)
+		}
+	}
+}
+
+func TestRepairOrderRestart(t *testing.T) {
+	type Data struct {
+		names      []string
+		urls       []string
+		expect     []string
+		duplicates int
+	}
+	data := []Data{
+		// Numbers restart in volumes, which are only in <dt> headers
+		Data{[]string{"第1章 A", "第2章 B", "第3章 C", "第1章 D", "第2章 E"}, []string{"1", "2", "3", "4", "5"}, []string{"第1章 A", "第2章 B", "第3章 C", "第1章 D", "第2章 E"}, 0},
+		// and chapters have no names
+		Data{[]string{"第1章", "第2章", "第3章", "第1章", "第2章"}, []string{"1", "2", "3", "4", "5"}, []string{"第1章", "第2章", "第3章", "第1章", "第2章"}, 0},
+		// with up-to-date chapters at the top, and swapped chapters in the second volume
+		Data{[]string{"第2章 E", "第1章 A", "第2章 B", "第3章 C", "第2章 E", "第1章 D"}, []string{"5", "1", "2", "3", "5", "4"}, []string{"第1章 A", "第2章 B", "第3章 C", "第1章 D", "第2章 E"}, 1},
+	}
+	for _, d := range data {
+		var c extract.Chapters
+		for i, name := range d.names {
+			c = append(c, &extract.Chapter{Name: name, Url: d.urls[i]})
+		}
+		rets, r := extract.RepairOrder(c)
+		if got := names(rets); len(got) != len(d.expect) {
+			t.Errorf("Get %v from %v. Expect %v.\n", got, d.names, d.expect)
+		} else {
+			for i := range got {
+				if got[i] != d.expect[i] {
+					t.Errorf("Get %v from %v. Expect %v.\n", got, d.names, d.expect)
+					break
+				}
+			}
+		}
+		if len(r.Duplicates) != d.duplicates {
+			t.Errorf("Get %v duplicates from %v. Expect %v.\n", len(r.Duplicates), d.names, d.duplicates)
+		}
+		if gaps := c.Gaps(); len(gaps) != 0 {
+			t.Errorf("Get %v from %v. Expect none.\n", gaps, d.names)
+		}
+	}
+}
+
+func TestGaps(t *testing.T) {
+	var c extract.Chapters
+	for _, name := range []string{"第339章", "第340章", "第344章", "第345章", "第347章"} {
+		c = append(c, &extract.Chapter{Name: name})
+	}
+	expects := []string{"chapters 341–343 missing", "chapter 346 missing"}
+	gaps := c.Gaps()
+	if len(gaps) != len(expects) {
+		t.Fatalf("Get %v. Expect %v.\n", gaps, expects)
+	}
+	for i := range gaps {
+		if gaps[i].String() != expects[i] {
+			t.Errorf("Get %v. Expect %v.\n", gaps[i], expects[i])
+		}
+	}
+}
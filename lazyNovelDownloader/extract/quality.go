@@ -0,0 +1,160 @@
+// quality score contents of chapters, so that the better one is chosen when merging catalogues.
+package extract
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	minimumParagraphs  = 5    // below which paragraphs are perceived as too few
+	minimumPunctuation = 0.02 // ratio of sentence punctuations to runes, below which sentences are broken
+	maximumPunctuation = 0.3  // and above which text is garbage
+	garbledPenalty     = 10   // a garbled rune costs as much as ten normal ones
+	imageOnlyQuality   = 0.5  // of every item for contents of images only, which lose to texts but are not garbage
+	weirdSymbols       = "&;()~@#%^*+<>/\\|{}[]="
+	// endingRunes end paragraphs. Paragraphs ending otherwise are probably broken.
+	endingRunes = "。！？!?…”」』）)—~～\"'"
+)
+
+// mojibakePattern match text decoded in wrong encodings, e.g. `锟斤拷`, `Ã©`, `&nbsp;`.
+var mojibakePattern = regexp.MustCompile(`锟斤拷|烫烫|屯屯|[ÃÂâ][\x{0080}-\x{00BF}]|&(?:#\d+|[a-z]+);`)
+
+// QualityScorer give a score to content of chapter. Higher is better.
+type QualityScorer interface {
+	Score(c *Chapter) float64
+}
+
+// Quality is the breakdown of score given by `WeightedScorer`. Every item is in [0, 1], and higher is better.
+type Quality struct {
+	CJK         float64 // ratio of CJK runes and punctuations
+	Ads         float64 // 1 - ratio of lines with ads
+	Paragraphs  float64 // enough paragraphs of reasonable length
+	Punctuation float64 // ratio of sentence punctuations in a sane range
+	Garbled     float64 // 1 - ratio of garbled runes
+}
+
+func (q Quality) String() string {
+	return fmt.Sprintf("CJK %.2f, Ads %.2f, Paragraphs %.2f, Punctuation %.2f, Garbled %.2f", q.CJK, q.Ads, q.Paragraphs, q.Punctuation, q.Garbled)
+}
+
+// QualityReporter is implemented by scorers able to give the breakdown of score, e.g. `WeightedScorer`.
+type QualityReporter interface {
+	Quality(content string) Quality
+}
+
+// describeScore tell where content of c comes from and how it is scored, e.g. `example.com 0.912 (CJK 0.95, ...)`.
+func describeScore(c *Chapter) string {
+	if c.Quality == nil {
+		return fmt.Sprintf("%s %.3f", c.Source, c.Score)
+	}
+	return fmt.Sprintf("%s %.3f (%v)", c.Source, c.Score, *c.Quality)
+}
+
+// scoreChapter record score of c given by scorer, together with its breakdown if scorer is a `QualityReporter`.
+func scoreChapter(c *Chapter, scorer QualityScorer) {
+	c.Score, c.Quality = scorer.Score(c), nil
+	if r, ok := scorer.(QualityReporter); ok && c.Fetch {
+		q := r.Quality(c.Content)
+		c.Quality = &q
+	}
+}
+
+// WeightedScorer score contents by the weighted average of `Quality`.
+type WeightedScorer struct {
+	CJK, Ads, Paragraphs, Punctuation, Garbled float64 // Weights
+	// Patterns match lines of ads.
+	Patterns []*regexp.Regexp
+}
+
+// NewWeightedScorer create WeightedScorer with default weights and built-in patterns of ads.
+func NewWeightedScorer() *WeightedScorer {
+	return &WeightedScorer{CJK: 2, Ads: 1, Paragraphs: 1, Punctuation: 1, Garbled: 3, Patterns: NewCleaner().Patterns}
+}
+
+// DefaultScorer is used when no scorer is given.
+var DefaultScorer QualityScorer = NewWeightedScorer()
+
+// Quality give the breakdown of score of content. Placeholders of images are not counted as texts.
+func (s *WeightedScorer) Quality(content string) (q Quality) {
+	hasImages := imagePattern.MatchString(content)
+	content = withoutImages(content)
+	var runes, cjk, punctuation, garbled int
+	for _, r := range content {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		runes++
+		switch {
+		case strings.ContainsRune(commaRunes, r):
+			cjk++
+			punctuation++
+		case unicode.Is(unicode.Han, r) || (unicode.IsPunct(r) && r > unicode.MaxLatin1):
+			cjk++
+		case r == utf8.RuneError || unicode.Is(unicode.Co, r) || unicode.IsControl(r) || strings.ContainsRune(weirdSymbols, r):
+			garbled++
+		}
+	}
+	if runes == 0 {
+		if hasImages {
+			q = Quality{imageOnlyQuality, imageOnlyQuality, imageOnlyQuality, imageOnlyQuality, imageOnlyQuality}
+		}
+		return
+	}
+	q.CJK = float64(cjk) / float64(runes)
+
+	garbled += len(mojibakePattern.FindAllString(content, -1))
+	if q.Garbled = 1 - float64(garbled*garbledPenalty)/float64(runes); q.Garbled < 0 {
+		q.Garbled = 0
+	}
+
+	switch ratio := float64(punctuation) / float64(runes); {
+	case ratio < minimumPunctuation:
+		q.Punctuation = ratio / minimumPunctuation
+	case ratio > maximumPunctuation:
+		q.Punctuation = maximumPunctuation / ratio
+	default:
+		q.Punctuation = 1
+	}
+
+	lines := lines(content)
+	ads, ended := 0, 0
+	for _, line := range lines {
+		if r, _ := utf8.DecodeLastRuneInString(line); strings.ContainsRune(endingRunes, r) {
+			ended++
+		}
+		for _, p := range s.Patterns {
+			if p.MatchString(line) {
+				ads++
+				break
+			}
+		}
+	}
+	q.Ads = 1 - float64(ads)/float64(len(lines))
+
+	q.Paragraphs = 1
+	if len(lines) < minimumParagraphs {
+		q.Paragraphs = float64(len(lines)) / minimumParagraphs
+	}
+	if average := float64(runes) / float64(len(lines)); average < minimumParagraphLength {
+		q.Paragraphs *= average / minimumParagraphLength // Lines are broken.
+	}
+	q.Paragraphs *= (1 + float64(ended)/float64(len(lines))) / 2
+	return
+}
+
+// Score give the weighted average of `Quality` in [0, 1]. Chapters not fetched get 0.
+func (s *WeightedScorer) Score(c *Chapter) float64 {
+	if !c.Fetch {
+		return 0
+	}
+	total := s.CJK + s.Ads + s.Paragraphs + s.Punctuation + s.Garbled
+	if total == 0 {
+		return 0
+	}
+	q := s.Quality(c.Content)
+	return (q.CJK*s.CJK + q.Ads*s.Ads + q.Paragraphs*s.Paragraphs + q.Punctuation*s.Punctuation + q.Garbled*s.Garbled) / total
+}
@@ -0,0 +1,42 @@
+package extract_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func TestWeightedScorer(t *testing.T) {
+	good := strings.Repeat("    克莱恩推开门，走进了冰冷的夜色中，街道两侧的煤气路灯散发着昏黄的光芒。\n", 6)
+	type Data struct {
+		name    string
+		content string
+	}
+	// Every content is worse than good.
+	data := []Data{
+		Data{"ads", good + strings.Repeat("    天才一秒记住本站地址：www.xxx.com\n", 3)},
+		Data{"garbled", strings.Replace(good, "夜色", "锟斤拷", -1)},
+		Data{"broken lines", strings.Replace(good, "，", "\n    ", -1)},
+		Data{"no punctuation", strings.NewReplacer("，", "", "。", "").Replace(good)},
+		Data{"latin", strings.Repeat("    Lorem ipsum dolor sit amet, consectetur adipiscing elit.\n", 6)},
+	}
+	scorer := extract.NewWeightedScorer()
+	best := scorer.Score(&extract.Chapter{Content: good, Fetch: true})
+	for _, d := range data {
+		if s := scorer.Score(&extract.Chapter{Content: d.content, Fetch: true}); s >= best {
+			t.Errorf("Get %v from %v. Expect less than %v.\n", s, d.name, best)
+		}
+	}
+	if s := scorer.Score(&extract.Chapter{Content: good}); s != 0 {
+		t.Errorf("Get %v from chapter not fetched. Expect 0.\n", s)
+	}
+	// Placeholders of images are not garbled texts.
+	image := "    " + extract.ImageText("https://example.com/img/1001/1.jpg?w=800&h=600") + "\n"
+	if s := scorer.Score(&extract.Chapter{Content: good + image, Fetch: true}); s != best {
+		t.Errorf("Get %v from good with image. Expect %v.\n", s, best)
+	}
+	if s := scorer.Score(&extract.Chapter{Content: image, Fetch: true}); s <= 0 || s >= best {
+		t.Errorf("Get %v from images only. Expect in (0, %v).\n", s, best)
+	}
+}
@@ -0,0 +1,240 @@
+// readability locate the body of chapter by scoring blocks, in the spirit of Readability.
+package extract
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+	"golang.org/x/net/html"
+)
+
+const (
+	minimumParagraphLength = 10  // in runes
+	minimumBodyLength      = 100 // in runes
+	classWeight            = 25
+	paragraphLengthUnit    = 100 // a point for every unit of runes
+	paragraphLengthPoints  = 3   // maximum points given by length
+)
+
+var (
+	// unlikelyTags never contain body of chapter.
+	unlikelyTags = utils.Candidates([]string{"script", "style", "noscript", "iframe", "form", "nav", "header", "footer", "aside", "select", "button", "textarea"})
+	// blockTags start a new line when extracting text.
+	blockTags   = utils.Candidates([]string{"p", "div", "section", "article", "td", "li", "dd", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "pre", "center"})
+	headingTags = utils.Candidates([]string{"h1", "h2", "h3", "h4", "h5", "h6"})
+	// inlineTags are part of surrounding paragraph.
+	inlineTags = utils.Candidates([]string{"span", "font", "b", "i", "em", "strong", "u", "small", "big", "sub", "sup", "label", "code"})
+	// containerTags may hold body of chapter.
+	containerTags      = utils.Candidates([]string{"div", "article", "section", "td", "dd", "blockquote", "pre", "center", "body"})
+	positiveClassNames = regexp.MustCompile(`(?i)content|chapter|article|text|txt|read|body|main|story|zw|nr`)
+	negativeClassNames = regexp.MustCompile(`(?i)side|foot|header|comment|nav|menu|banner|\bads?\b|adv|recommend|share|copyright|links|tags|rank|login`)
+	// commaRunes are counted as a sign of real sentences.
+	commaRunes = "，,。、！？；"
+)
+
+func attrValue(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// classScore reward or punish blocks according to their class and id.
+func classScore(n *html.Node) (ret float64) {
+	for _, name := range []string{attrValue(n, "class"), attrValue(n, "id")} {
+		if name == "" {
+			continue
+		}
+		if negativeClassNames.MatchString(name) {
+			ret -= classWeight
+		}
+		if positiveClassNames.MatchString(name) {
+			ret += classWeight
+		}
+	}
+	return
+}
+
+func isUnlikely(n *html.Node) bool {
+	return n.Type == html.ElementNode && unlikelyTags.Contains(n.Data)
+}
+
+// innerText give the text under n, with link text omitted or not.
+func innerText(n *html.Node, withLink bool) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if isUnlikely(n) || (!withLink && n.Type == html.ElementNode && n.Data == "a") {
+			return
+		}
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(b.String())
+}
+
+// linkDensity give the ratio of text under <a> to all text.
+func linkDensity(n *html.Node) float64 {
+	all := utf8.RuneCountInString(innerText(n, true))
+	if all == 0 {
+		return 1
+	}
+	return float64(all-utf8.RuneCountInString(innerText(n, false))) / float64(all)
+}
+
+// paragraph is a piece of text belonging to a container, either a <p> or text directly separated by <br>.
+type paragraph struct {
+	container *html.Node
+	text      string
+}
+
+// collectParagraphs walk the tree and gather paragraphs.
+func collectParagraphs(root *html.Node) (rets []paragraph) {
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if isUnlikely(n) {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == "p" {
+			if n.Parent != nil {
+				rets = append(rets, paragraph{container: n.Parent, text: innerText(n, false)})
+			}
+			return
+		}
+		// Text directly under a container, separated by <br> or inline elements.
+		var direct strings.Builder
+		var flush = func() {
+			if t := strings.TrimSpace(direct.String()); t != "" {
+				rets = append(rets, paragraph{container: n, text: t})
+			}
+			direct.Reset()
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case c.Type == html.TextNode:
+				direct.WriteString(c.Data)
+			case c.Type == html.ElementNode && c.Data == "br":
+				flush()
+			case c.Type == html.ElementNode && inlineTags.Contains(c.Data):
+				direct.WriteString(innerText(c, false))
+			default:
+				flush()
+				walk(c)
+			}
+		}
+		flush()
+	}
+	walk(root)
+	return
+}
+
+// paragraphScore give points to paragraph according to its length and punctuation.
+func paragraphScore(text string) float64 {
+	length := utf8.RuneCountInString(text)
+	if length < minimumParagraphLength {
+		return 0
+	}
+	ret := 1.0
+	for _, r := range text {
+		if strings.ContainsRune(commaRunes, r) {
+			ret++
+		}
+	}
+	if points := float64(length / paragraphLengthUnit); points < paragraphLengthPoints {
+		ret += points
+	} else {
+		ret += paragraphLengthPoints
+	}
+	return ret
+}
+
+// bodyText extract text under root line by line. Links, headings and inner blocks judged as noise by class or id are omitted.
+func bodyText(root *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if isUnlikely(n) {
+			return
+		}
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			if n.Data == "a" || headingTags.Contains(n.Data) { // Chapter name is written separately.
+				return
+			}
+			if n.Data == "br" {
+				b.WriteString("\n")
+				return
+			}
+			if blockTags.Contains(n.Data) {
+				if n != root && classScore(n) < 0 {
+					return
+				}
+				b.WriteString("\n")
+				defer b.WriteString("\n")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+	return b.String()
+}
+
+// ReadableText find the block holding body of chapter, and return its text line by line.
+// Paragraphs give points to their containers and half to grandparents according to length and punctuation.
+// Containers are then weighted by class/id and link density, and the best one wins.
+// false is returned when no block is long enough, in which case callers should fall back to other methods.
+func ReadableText(root *html.Node) (string, bool) {
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+	var addScore = func(n *html.Node, s float64) {
+		if n == nil || n.Type != html.ElementNode || !containerTags.Contains(n.Data) {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			candidates = append(candidates, n)
+			scores[n] = classScore(n)
+		}
+		scores[n] += s
+	}
+	for _, p := range collectParagraphs(root) {
+		s := paragraphScore(p.text)
+		if s == 0 {
+			continue
+		}
+		addScore(p.container, s)
+		if p.container.Parent != nil {
+			addScore(p.container.Parent, s/2)
+		}
+	}
+
+	var best *html.Node
+	var bestScore float64
+	for _, c := range candidates {
+		s := scores[c] * (1 - linkDensity(c))
+		if best == nil || s > bestScore {
+			best, bestScore = c, s
+		}
+	}
+	if best == nil {
+		return "", false
+	}
+	text := bodyText(best)
+	if utf8.RuneCountInString(strings.TrimSpace(text)) < minimumBodyLength {
+		return "", false
+	}
+	return text, true
+}
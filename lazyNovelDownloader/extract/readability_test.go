@@ -0,0 +1,51 @@
+package extract_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"golang.org/x/net/html"
+)
+
+func TestReadableText(t *testing.T) {
+	type Data struct {
+		fixture string
+		ok      bool
+		include []string
+		exclude []string
+	}
+	data := []Data{
+		Data{fixture: "sidebar.html", ok: true, include: []string{"头好痛！", "一切都显得陌生而又真实。"}, exclude: []string{"凡人修仙传", "下一章", "本站所有小说"}},
+		Data{fixture: "paragraphs.html", ok: true, include: []string{"大口喘息", "一片刺目的红光"}, exclude: []string{"第二章", "请收藏本站", "求更新", "Copyright"}},
+		Data{fixture: "table.html", ok: true, include: []string{"是正面。", "久久不散"}, exclude: []string{"排行榜", "章节列表"}},
+		Data{fixture: "short.html", ok: false},
+	}
+	for _, d := range data {
+		raw, err := ioutil.ReadFile(filepath.Join("testdata", "content", d.fixture))
+		if err != nil {
+			t.Fatalf("Read fixture %s: %v\n", d.fixture, err)
+		}
+		doc, err := html.Parse(strings.NewReader(string(raw)))
+		if err != nil {
+			t.Fatalf("Parse fixture %s: %v\n", d.fixture, err)
+		}
+		text, ok := extract.ReadableText(doc)
+		if ok != d.ok {
+			t.Errorf("Get %v from %s. Expect %v.\n", ok, d.fixture, d.ok)
+			continue
+		}
+		for _, s := range d.include {
+			if !strings.Contains(text, s) {
+				t.Errorf("Get %q from %s. Expect including %q.\n", text, d.fixture, s)
+			}
+		}
+		for _, s := range d.exclude {
+			if strings.Contains(text, s) {
+				t.Errorf("Get %q from %s. Expect excluding %q.\n", text, d.fixture, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// select pick a part of the catalogue before any content is fetched.
+package extract
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+// Selection describe which chapters of a catalogue should be kept.
+// Indexes are 1-based and inclusive, which is the same as what `lnd catalog` shows.
+// Catalogues to be merged are selected once by indexes in their merged catalogue. See `SelectCatalogs`.
+type Selection struct {
+	From int // 0 means from the first chapter.
+	To   int // 0 means to the last chapter.
+	// Last keeps only the last N chapters after range and title filters. 0 means all.
+	Last int
+	// Include keeps chapters whose name matches. nil means all.
+	Include *regexp.Regexp
+	// Exclude drops chapters whose name matches. nil means none.
+	Exclude *regexp.Regexp
+}
+
+// Empty tells whether the selection keeps every chapter.
+func (s *Selection) Empty() bool {
+	return s == nil || (s.From == 0 && s.To == 0 && s.Last == 0 && s.Include == nil && s.Exclude == nil)
+}
+
+// ParseRange parse range in the form of `100-250`, `100-`, `-250` or `42`.
+func ParseRange(r string) (from int, to int, e error) {
+	r = strings.TrimSpace(r)
+	if r == "" {
+		return 0, 0, nil
+	}
+	pos := strings.Index(r, "-")
+	if pos == -1 {
+		from, e = strconv.Atoi(r)
+		if e != nil || from <= 0 {
+			return 0, 0, utils.Invalid
+		}
+		return from, from, nil
+	}
+	head, tail := strings.TrimSpace(r[:pos]), strings.TrimSpace(r[pos+1:])
+	if head == "" && tail == "" {
+		return 0, 0, utils.Invalid
+	}
+	if head != "" {
+		if from, e = strconv.Atoi(head); e != nil || from <= 0 {
+			return 0, 0, utils.Invalid
+		}
+	}
+	if tail != "" {
+		if to, e = strconv.Atoi(tail); e != nil || to <= 0 {
+			return 0, 0, utils.Invalid
+		}
+	}
+	if from > 0 && to > 0 && from > to {
+		return 0, 0, utils.Invalid
+	}
+	return from, to, nil
+}
+
+// Select return chapters chosen by s. The original catalogue is not modified.
+func (c Chapters) Select(s *Selection) (rets Chapters) {
+	if s.Empty() {
+		return c
+	}
+	from, to := 1, len(c)
+	if s.From > 0 {
+		from = s.From
+	}
+	if s.To > 0 && s.To < to {
+		to = s.To
+	}
+	for i := from - 1; i < to; i++ {
+		if s.Include != nil && !s.Include.MatchString(c[i].Name) {
+			continue
+		}
+		if s.Exclude != nil && s.Exclude.MatchString(c[i].Name) {
+			continue
+		}
+		rets = append(rets, c[i])
+	}
+	if s.Last > 0 && len(rets) > s.Last {
+		rets = rets[len(rets)-s.Last:]
+	}
+	return
+}
+
+// SelectCatalogs select the same chapters of catalogues to be merged, by positions in their consensus order. See `MergeCatalogs`.
+// NOTICE: Catalogues selected one by one may keep different chapters, when some have extra announcements or latest chapters.
+func SelectCatalogs(c_s []Chapters, s *Selection) []Chapters {
+	if s.Empty() {
+		return c_s
+	}
+	keys := make([][]string, len(c_s), len(c_s))
+	representatives := make(map[string]*Chapter)
+	for i, c := range c_s {
+		keys[i] = c.Keys()
+		for j, key := range keys[i] {
+			if _, ok := representatives[key]; !ok {
+				representatives[key] = c[j]
+			}
+		}
+	}
+	order, _ := consensusOrder(keys)
+	all := make(Chapters, len(order), len(order))
+	keyOf := make(map[*Chapter]string)
+	for i, key := range order {
+		all[i] = representatives[key]
+		keyOf[all[i]] = key
+	}
+	selected := make(map[string]bool)
+	for _, c := range all.Select(s) {
+		selected[keyOf[c]] = true
+	}
+	rets := make([]Chapters, len(c_s), len(c_s))
+	for i, c := range c_s {
+		for j, chapter := range c {
+			if selected[keys[i][j]] {
+				rets[i] = append(rets[i], chapter)
+			}
+		}
+	}
+	return rets
+}
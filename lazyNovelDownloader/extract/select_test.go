@@ -0,0 +1,87 @@
+package extract_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func TestParseRange(t *testing.T) {
+	type Data struct {
+		r       string
+		from    int
+		to      int
+		invalid bool
+	}
+	data := []Data{
+		Data{"100-250", 100, 250, false},
+		Data{"100-", 100, 0, false},
+		Data{"-250", 0, 250, false},
+		Data{"42", 42, 42, false},
+		Data{" 2 - 4 ", 2, 4, false},
+		Data{"", 0, 0, false},
+		Data{"5-3", 0, 0, true},
+		Data{"0", 0, 0, true},
+		Data{"a-b", 0, 0, true},
+		Data{"-0", 0, 0, true},
+		Data{"-", 0, 0, true},
+	}
+	for _, d := range data {
+		from, to, err := extract.ParseRange(d.r)
+		if from != d.from || to != d.to || (err != nil) != d.invalid {
+			t.Errorf("Get %v, %v, %v from %q. Expect %v, %v, invalid: %v.\n", from, to, err, d.r, d.from, d.to, d.invalid)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	c := catalogue("第1章 A", "第2章 B", "请假条", "第3章 C", "第4章 D", "第5章 E")
+	type Data struct {
+		s      *extract.Selection
+		expect []string
+	}
+	data := []Data{
+		Data{nil, names(c)},
+		Data{&extract.Selection{From: 4}, []string{"第3章 C", "第4章 D", "第5章 E"}},
+		Data{&extract.Selection{To: 2}, []string{"第1章 A", "第2章 B"}},
+		Data{&extract.Selection{From: 2, To: 4}, []string{"第2章 B", "请假条", "第3章 C"}},
+		Data{&extract.Selection{Last: 2}, []string{"第4章 D", "第5章 E"}},
+		Data{&extract.Selection{To: 4, Last: 2}, []string{"请假条", "第3章 C"}},
+		Data{&extract.Selection{Include: regexp.MustCompile(`^第\d+章`)}, []string{"第1章 A", "第2章 B", "第3章 C", "第4章 D", "第5章 E"}},
+		Data{&extract.Selection{Exclude: regexp.MustCompile(`请假`), Last: 3}, []string{"第3章 C", "第4章 D", "第5章 E"}},
+		Data{&extract.Selection{From: 7}, nil},
+	}
+	for _, d := range data {
+		if ret := names(c.Select(d.s)); !reflect.DeepEqual(ret, d.expect) {
+			t.Errorf("Get %v from %+v. Expect %v.\n", ret, d.s, d.expect)
+		}
+	}
+}
+
+func TestSelectCatalogs(t *testing.T) {
+	c_s := []extract.Chapters{
+		catalogue("第1章 A", "第2章 B", "第3章 C", "第4章 D", "第5章 E"),
+		// Extra announcements shift positions.
+		catalogue("公告", "上架感言", "1.A", "2.B", "3.C", "4.D", "5.E"),
+	}
+	type Data struct {
+		s       *extract.Selection
+		expects [][]string
+	}
+	data := []Data{
+		// Positions are in the merged catalogue, i.e. `公告`, `上架感言`, `第1章 A`, ...
+		Data{&extract.Selection{From: 4, To: 5}, [][]string{[]string{"第2章 B", "第3章 C"}, []string{"2.B", "3.C"}}},
+		Data{&extract.Selection{Last: 2}, [][]string{[]string{"第4章 D", "第5章 E"}, []string{"4.D", "5.E"}}},
+		Data{&extract.Selection{Include: regexp.MustCompile(`B|公告`)}, [][]string{[]string{"第2章 B"}, []string{"公告", "2.B"}}},
+	}
+	for _, d := range data {
+		rets := extract.SelectCatalogs(c_s, d.s)
+		for i := range rets {
+			if ret := names(rets[i]); !reflect.DeepEqual(ret, d.expects[i]) {
+				t.Errorf("Get %v from catalogue %v with %+v. Expect %v.\n", ret, i, d.s, d.expects[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// title parse names of chapters into volume, number and clean name, so that the same chapter on different sites can be matched.
+package extract
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/chinese"
+)
+
+const (
+	chineseNumerals = "0-9零〇一二两三四五六七八九十百千万"
+)
+
+var (
+	volumePattern = regexp.MustCompile(`^第\s*([` + chineseNumerals + `]+)\s*[卷部集篇]`)
+	numberPattern = []*regexp.Regexp{
+		regexp.MustCompile(`^第\s*([` + chineseNumerals + `]+)\s*[章节回话幕]`),
+		regexp.MustCompile(`^(?i)chapter\s*([0-9]+)`),
+		regexp.MustCompile(`^([0-9]+)(?:[.、．:：\-_\s]|$)`),
+	}
+	// promotionPattern match promotional suffixes, e.g. `（求月票）`, `【加更】`, `求推荐`.
+	promotionPattern = regexp.MustCompile(`\s*(?:[（(【\[<《][^）)】\]>》]*(?:求|票|推荐|收藏|订阅|更|打赏|盟主|爆发)[^）)】\]>》]*[）)】\]>》]|求(?:月票|推荐票?|收藏|订阅|打赏)+[!！]*)\s*$`)
+	separatorRunes   = " 　:：.、．-—_·,，|"
+)
+
+var chineseDigits = map[rune]int{'零': 0, '〇': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+var chineseUnits = map[rune]int{'十': 10, '百': 100, '千': 1000, '万': 10000}
+
+// ParseNumber parse Arabic or Chinese numerals, e.g. `120`, `一百二十`, `一二零`, `十二`.
+// false is returned if s is not a number.
+func ParseNumber(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	hasUnit := false
+	for _, r := range s {
+		if _, ok := chineseUnits[r]; ok {
+			hasUnit = true
+		} else if _, ok := chineseDigits[r]; !ok {
+			return 0, false
+		}
+	}
+	if !hasUnit { // Read digit by digit, e.g. `一二零`.
+		ret := 0
+		for _, r := range s {
+			ret = ret*10 + chineseDigits[r]
+		}
+		return ret, true
+	}
+	total, section, digit := 0, 0, 0
+	for _, r := range s {
+		if d, ok := chineseDigits[r]; ok {
+			digit = d
+			continue
+		}
+		unit := chineseUnits[r]
+		if unit == 10000 {
+			total += (section + digit) * unit
+			section, digit = 0, 0
+			continue
+		}
+		if digit == 0 && unit == 10 { // `十二` means `一十二`
+			digit = 1
+		}
+		section += digit * unit
+		digit = 0
+	}
+	return total + section + digit, true
+}
+
+// Title is the parsed name of chapter.
+type Title struct {
+	Raw    string
+	Volume int // 0 means absent
+	Number int // 0 means absent
+	Name   string
+}
+
+// normalizeText convert full-width characters to half-width, remove spaces and lower the case.
+func normalizeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '　':
+			continue
+		case r >= '！' && r <= '～':
+			r -= '！' - '!'
+		}
+		if unicode.IsSpace(r) {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func matchNumber(s string) bool {
+	for _, p := range numberPattern {
+		if p.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTitle parse name of chapter, e.g. `第一卷 第一百二十章 xxx（求月票）`, `120.xxx`, `第120章xxx`.
+// Traditional Chinese is converted to Simplified Chinese first, so that Name of `第兩百章 開始` is the same as `第二百章 开始`.
+func ParseTitle(s string) (t Title) {
+	t.Raw = s
+	rest := chinese.ToSimplified(strings.TrimSpace(s))
+	// Full-width digits are common.
+	rest = strings.Map(func(r rune) rune {
+		if r >= '０' && r <= '９' {
+			return r - '０' + '0'
+		}
+		return r
+	}, rest)
+	if m := volumePattern.FindStringSubmatch(rest); m != nil {
+		t.Volume, _ = ParseNumber(m[1])
+		rest = strings.TrimLeft(rest[len(m[0]):], separatorRunes)
+		// Name of volume, if exists, is before the chapter number.
+		if !matchNumber(rest) {
+			if i := strings.Index(rest, "第"); i > 0 && matchNumber(rest[i:]) {
+				rest = rest[i:]
+			}
+		}
+	}
+	for _, p := range numberPattern {
+		if m := p.FindStringSubmatch(rest); m != nil {
+			if n, ok := ParseNumber(m[1]); ok {
+				t.Number = n
+				rest = rest[len(m[0]):]
+				break
+			}
+		}
+	}
+	for {
+		trimmed := promotionPattern.ReplaceAllString(rest, "")
+		if trimmed == rest {
+			break
+		}
+		rest = trimmed
+	}
+	t.Name = strings.Trim(rest, separatorRunes)
+	return
+}
+
+// Key give identity of chapter. Chapters with numbers are identified by numbers, otherwise by normalized names.
+func (t Title) Key() string {
+	if t.Number > 0 {
+		return "#" + strconv.Itoa(t.Number)
+	}
+	return normalizeText(t.Name)
+}
+
+// Keys give identities of chapters in order. The n th(n > 1) appearance of the same identity is
+// suffixed with `@n`, since some sites restart numbering in every volume.
+func (c Chapters) Keys() []string {
+	rets := make([]string, len(c), len(c))
+	appears := make(map[string]int)
+	for i, chapter := range c {
+		key := ParseTitle(chapter.Name).Key()
+		appears[key]++
+		if appears[key] > 1 {
+			key += "@" + strconv.Itoa(appears[key])
+		}
+		rets[i] = key
+	}
+	return rets
+}
@@ -0,0 +1,78 @@
+package extract_test
+
+import (
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func TestParseNumber(t *testing.T) {
+	type Data struct {
+		s      string
+		expect int
+		ok     bool
+	}
+	data := []Data{
+		Data{"120", 120, true},
+		Data{"一百二十", 120, true},
+		Data{"十二", 12, true},
+		Data{"一千零一", 1001, true},
+		Data{"两百", 200, true},
+		Data{"一二零", 120, true},
+		Data{"二十万零三", 200003, true},
+		Data{"一百a", 0, false},
+		Data{"", 0, false},
+	}
+	for _, d := range data {
+		if n, ok := extract.ParseNumber(d.s); n != d.expect || ok != d.ok {
+			t.Errorf("Get %v, %v from %v. Expect %v, %v.\n", n, ok, d.s, d.expect, d.ok)
+		}
+	}
+}
+
+func TestParseTitle(t *testing.T) {
+	type Data struct {
+		s      string
+		volume int
+		number int
+		name   string
+	}
+	data := []Data{
+		Data{"第一百二十章 风暴之主", 0, 120, "风暴之主"},
+		Data{"第120章风暴之主（求月票）", 0, 120, "风暴之主"},
+		Data{"１２０.风暴之主", 0, 120, "风暴之主"},
+		Data{"第一卷 小丑 第三章 占卜", 1, 3, "占卜"},
+		Data{"Chapter 7: The Fool", 0, 7, "The Fool"},
+		Data{"风暴之主【第二更】求推荐", 0, 0, "风暴之主"},
+		Data{"上架感言", 0, 0, "上架感言"},
+		Data{"第一卷 小丑 第兩百章 風暴之主（求推薦）", 1, 200, "风暴之主"},
+		Data{"上架感言（求訂閱）", 0, 0, "上架感言"},
+	}
+	for _, d := range data {
+		title := extract.ParseTitle(d.s)
+		if title.Volume != d.volume || title.Number != d.number || title.Name != d.name {
+			t.Errorf("Get %+v from %v. Expect %v, %v, %v.\n", title, d.s, d.volume, d.number, d.name)
+		}
+	}
+}
+
+func TestMergeCatalogByTitle(t *testing.T) {
+	u := extract.Chapters{
+		&extract.Chapter{Name: "第一章 绯红"},
+		&extract.Chapter{Name: "第二章 情况"},
+		&extract.Chapter{Name: "第三章 笔记"},
+	}
+	v := extract.Chapters{
+		&extract.Chapter{Name: "1.绯红"},
+		&extract.Chapter{Name: "2.情况（求收藏）"},
+		&extract.Chapter{Name: "3.笔记"},
+		&extract.Chapter{Name: "4.来客"},
+	}
+	rets := extract.MergeCatalog(u, v)
+	if len(rets) != 4 {
+		t.Fatalf("Get %v chapters. Expect 4.\n", len(rets))
+	}
+	if rets[3].Name != "4.来客" {
+		t.Errorf("Get %v. Expect %v.\n", rets[3].Name, "4.来客")
+	}
+}
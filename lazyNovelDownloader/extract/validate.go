@@ -0,0 +1,92 @@
+// validate find contents which are fetched successfully but wrong, e.g. placeholders, anti-scraper pages or previous chapters.
+package extract
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ProblemShort       = "too short"
+	ProblemDuplicate   = "same as previous chapter"
+	ProblemPlaceholder = "placeholder"
+)
+
+const (
+	minimumContentLength  = 50  // in runes
+	maximumPlaceholderLen = 300 // in runes, placeholders are short
+	simHashShingle        = 2   // in runes
+	simHashDistance       = 3   // in bits, contents within are near-identical
+	maximumValidateRetry  = 2   // times of re-fetching a suspicious chapter
+)
+
+// placeholderPattern match pages of `updating`, `typing` and anti-scraper tests.
+var placeholderPattern = regexp.MustCompile(`(?i)章节(?:内容)?(?:正在)?更新中|正在手打中|内容(?:正在)?(?:手打|加载)|加载失败|请稍后(?:再)?(?:刷新|访问|重试)|防采集|访问(?:过于)?频繁|验证码|access denied|forbidden|just a moment|cloudflare|too many requests`)
+
+// Issue describe a suspicious content in catalogue.
+type Issue struct {
+	Index   int
+	Problem string
+}
+
+// SimHash give 64-bit similarity hash of text, using shingles of runes.
+// Near-identical texts have hashes of small hamming distance.
+func SimHash(text string) uint64 {
+	var weights [64]int
+	runes := []rune(text)
+	for i := 0; i+simHashShingle <= len(runes); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(string(runes[i : i+simHashShingle])))
+		v := h.Sum64()
+		for b := 0; b < 64; b++ {
+			if v&(1<<uint(b)) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+	var ret uint64
+	for b := 0; b < 64; b++ {
+		if weights[b] > 0 {
+			ret |= 1 << uint(b)
+		}
+	}
+	return ret
+}
+
+// NearIdentical tells whether two texts are nearly the same.
+func NearIdentical(u, v string) bool {
+	return bits.OnesCount64(SimHash(u)^SimHash(v)) <= simHashDistance
+}
+
+// ValidateContents inspect fetched chapters, and report ones which are too short, placeholders,
+// or near-identical to the previous fetched chapter, with the same images if any.
+func ValidateContents(c Chapters) (rets []Issue) {
+	var prevHash uint64
+	var prevImages string
+	prevValid := false
+	for i, chapter := range c {
+		if !chapter.Fetch {
+			prevValid = false
+			continue
+		}
+		// NOTICE: Placeholders of images are not texts, and chapters of images only are never short.
+		text, images := withoutImages(chapter.Content), strings.Join(Images(chapter.Content), "\n")
+		length := utf8.RuneCountInString(text)
+		hash := SimHash(text)
+		switch {
+		case length <= maximumPlaceholderLen && placeholderPattern.MatchString(text):
+			rets = append(rets, Issue{Index: i, Problem: ProblemPlaceholder})
+		case length < minimumContentLength && images == "":
+			rets = append(rets, Issue{Index: i, Problem: ProblemShort})
+		case prevValid && bits.OnesCount64(hash^prevHash) <= simHashDistance && images == prevImages:
+			rets = append(rets, Issue{Index: i, Problem: ProblemDuplicate})
+		}
+		prevHash, prevImages, prevValid = hash, images, length >= minimumContentLength || images != ""
+	}
+	return
+}
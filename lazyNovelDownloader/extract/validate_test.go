@@ -0,0 +1,64 @@
+package extract_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+)
+
+func TestValidateContents(t *testing.T) {
+	body := strings.Repeat("    克莱恩推开门，走进了冰冷的夜色中，街道两侧的煤气路灯散发着昏黄的光芒。\n", 5)
+	other := strings.Repeat("    远处的钟楼敲响了十一下，沉闷的回声在廷根市的上空久久不散，仿佛在提醒着什么。\n", 5)
+	chapters := extract.Chapters{
+		&extract.Chapter{Name: "1", Content: body, Fetch: true},
+		&extract.Chapter{Name: "2", Content: body + "    多了一行。\n", Fetch: true},
+		&extract.Chapter{Name: "3", Content: "    章节内容正在手打中，请稍后刷新。\n", Fetch: true},
+		&extract.Chapter{Name: "4", Content: "    请假一天。\n", Fetch: true},
+		&extract.Chapter{Name: "5", Content: other, Fetch: true},
+		&extract.Chapter{Name: "6", Fetch: false},
+		&extract.Chapter{Name: "7", Content: other, Fetch: true},
+	}
+	expects := []extract.Issue{
+		extract.Issue{Index: 1, Problem: extract.ProblemDuplicate},
+		extract.Issue{Index: 2, Problem: extract.ProblemPlaceholder},
+		extract.Issue{Index: 3, Problem: extract.ProblemShort},
+	}
+	issues := extract.ValidateContents(chapters)
+	if len(issues) != len(expects) {
+		t.Fatalf("Get %v. Expect %v.\n", issues, expects)
+	}
+	for i := range issues {
+		if issues[i] != expects[i] {
+			t.Errorf("Get %v. Expect %v.\n", issues[i], expects[i])
+		}
+	}
+}
+
+func TestValidateImages(t *testing.T) {
+	var images = func(urls ...string) (ret string) {
+		for _, url := range urls {
+			ret += "    " + extract.ImageText(url) + "\n"
+		}
+		return
+	}
+	chapters := extract.Chapters{
+		&extract.Chapter{Name: "1", Content: images("https://example.com/img/1001/1.jpg", "https://example.com/img/1001/2.jpg"), Fetch: true},
+		&extract.Chapter{Name: "2", Content: images("https://example.com/img/1002/1.jpg", "https://example.com/img/1002/2.jpg"), Fetch: true},
+		&extract.Chapter{Name: "3", Content: images("https://example.com/img/1002/1.jpg", "https://example.com/img/1002/2.jpg"), Fetch: true},
+		&extract.Chapter{Name: "4", Content: "    请稍后刷新。\n" + images("https://example.com/img/1004/1.jpg"), Fetch: true},
+	}
+	expects := []extract.Issue{
+		extract.Issue{Index: 2, Problem: extract.ProblemDuplicate},
+		extract.Issue{Index: 3, Problem: extract.ProblemPlaceholder},
+	}
+	issues := extract.ValidateContents(chapters)
+	if len(issues) != len(expects) {
+		t.Fatalf("Get %v. Expect %v.\n", issues, expects)
+	}
+	for i := range issues {
+		if issues[i] != expects[i] {
+			t.Errorf("Get %v. Expect %v.\n", issues[i], expects[i])
+		}
+	}
+}
@@ -2,67 +2,194 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/search"
 
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/chinese"
 	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/typography"
 	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/write"
 )
 
 var novelName = flag.String("name", "", "[compulsory] Novel Name")
-var novelAuthor = flag.String("author", "", "[optional] Novel Author")
+var novelAuthor = flag.String("author", "", "[optional] Novel Author, also used to search and verify catalogs")
 var outputFileName = flag.String("o", "", `[optional] Output File Name(can include path)`)
-var outputFileFormat = flag.String("format", "txt", "[optional] txt/epub")
+var outputFileFormat = flag.String("format", "txt", "[optional] txt/epub/json/jsonl")
 var catalogURL = flag.String("source", "", "[optional] URL for Catalog Html File of Novel")
 var autoDetection = flag.Bool("auto", false, "[optional] Whether to detect catalogs automatically, given the name of novel")
+var directSearch = flag.Bool("direct", false, "[optional] Whether to search novel sites directly instead of web search engines, when [auto] is given")
+var interactive = flag.Bool("interactive", false, "[optional] Whether to choose auto-detected catalogs by hand")
+var fetchFallback = flag.Bool("fallback", false, "[optional] Whether to fetch each chapter from one catalog and turn to others only when it fails, when [auto] is given")
+var provenance = flag.Bool("provenance", false, "[optional] Whether to record where every chapter comes from, in hidden footers of epub or a JSON file beside txt")
+var chineseScript = flag.String("chinese", "", "[optional] Convert names and contents to traditional/simplified Chinese before writing")
+var typographySpec = flag.String("typography", "", "[optional] Normalize punctuations before writing, e.g. default, or items of full/half,curly/corner,merge,ellipsis,dash")
+var cleanContent = flag.Bool("clean", true, "[optional] Whether to remove ads and watermarks from contents")
+var cleanRules = flag.String("rules", "", "[optional] File of extra cleaning rules, one per line. re: for regular expression, # for comment, others for exact line")
+var repeatRatio = flag.Float64("repeat", 0.3, "[optional] Lines appearing in more than this ratio of chapters are removed when cleaning. 0 disables it")
+var chapterRange = flag.String("range", "", "[optional] Range of chapters to download, e.g. 100-250, 100-, -250")
+var chapterFrom = flag.Int("from", 0, "[optional] Index of the first chapter to download")
+var chapterTo = flag.Int("to", 0, "[optional] Index of the last chapter to download")
+var chapterLast = flag.Int("last", 0, "[optional] Only download the last N chapters")
+var chapterMatch = flag.String("match", "", "[optional] Only download chapters whose title matches the regular expression")
+var chapterExclude = flag.String("exclude", "", "[optional] Skip chapters whose title matches the regular expression")
+
+var errInvalidSelection = errors.New("Invalid Chapter Selection.")
 
 const (
-	invalidPrompt = "Invalid Arguments! One of [source] and [auto] must be specified. Type in -help/-h for help."
+	invalidPrompt = "Invalid Arguments! One of [source] and [auto] must be specified, and [range] cannot be used with [from]/[to]. Type in -help/-h for help."
 	errorPrompt   = ", encounter Error %v. The Program is terminated unexpectedly."
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case catalogCommand:
+			catalog(os.Args[2:])
+			return
+		case convertCommand:
+			convert(os.Args[2:])
+			return
+		}
+	}
 	flag.Parse()
-	if len(flag.Args()) > 0 || (*outputFileFormat != "txt" && *outputFileFormat != "epub") || *novelName == "" || (*catalogURL == "" && !*autoDetection) {
+	if len(flag.Args()) > 0 || !validFormat(*outputFileFormat) || !validChinese(*chineseScript) || *novelName == "" || (*catalogURL == "" && !*autoDetection) {
 		log.Fatalf("%s", invalidPrompt)
 	}
 	if *outputFileName == "" {
 		*outputFileName = *novelName
 	}
+	selection, err := parseSelection()
+	if err != nil {
+		log.Fatalf("%s", invalidPrompt)
+	}
+	normalizer, err := typography.Parse(*typographySpec)
+	if err != nil {
+		log.Fatalf("%s", invalidPrompt)
+	}
+	metadata := &extract.Metadata{}
+	options := &extract.ExtractOption{Selection: selection, Fallback: *fetchFallback, Metadata: metadata}
+	if *cleanContent {
+		options.Cleaner = extract.NewCleaner()
+		options.Cleaner.RepeatRatio = *repeatRatio
+		if *cleanRules != "" {
+			if err = options.Cleaner.LoadRules(*cleanRules); err != nil {
+				log.Fatalf("While loading cleaning rules"+errorPrompt, err)
+			}
+		}
+	}
 	var c_s []extract.Chapters
 	var errs []error
 	if *catalogURL != "" {
-		c_s, errs = extract.Extract(os.Stdout, []string{*catalogURL}, *novelName, false, false)
+		c_s, errs = extract.Extract(os.Stdout, []string{*catalogURL}, *novelName, false, false, options)
 		if errs[0] != nil {
 			log.Fatalf("While extracting contents"+errorPrompt, errs[0])
 		}
 	} else { // Auto-Detection
-		urls, err := search.Search(os.Stdout, *novelName)
+		var results []search.Result
+		if *directSearch {
+			results, err = search.SearchSites(os.Stdout, *novelName, *novelAuthor, nil)
+		} else {
+			results, err = search.Search(os.Stdout, *novelName, *novelAuthor)
+		}
 		if err != nil {
 			log.Fatalf("While searching for catalogs"+errorPrompt, err)
 		}
-		c_s, errs = extract.Extract(os.Stdout, urls, *novelName, true, true)
+		urls := search.Urls(results)
+		options.Cached = !*directSearch // NOTICE: Pages have been fetched when verifying them.
+		if *interactive {
+			latest := search.Latests(results)
+			options.Choose = func(candidates []*extract.Candidate) []int { return chooseCandidates(candidates, latest) }
+		}
+		c_s, errs = extract.Extract(os.Stdout, urls, *novelName, true, true, options)
 		if errs[0] != nil {
 			log.Fatalf("While extracting contents"+errorPrompt, errs[0])
 		}
 	}
 
-	var err error
+	// NOTICE: Gaps are meaningless if chapters are filtered by names.
+	if selection.Include == nil && selection.Exclude == nil {
+		for _, gap := range c_s[0].Gaps() {
+			fmt.Printf("NOTICE: %s.\n", gap)
+		}
+	}
+
 	*outputFileName, err = filepath.Abs(*outputFileName)
 	if err != nil {
 		log.Fatalf("While getting output file path"+errorPrompt, err)
 	}
-	switch *outputFileFormat {
-	case "txt":
-		err = write.WriteToTxt(os.Stdout, c_s[0], *outputFileName, write.NovelInfo{Name: *novelName, Author: *novelAuthor})
-	case "epub":
-		err = write.WriteToEpub(os.Stdout, c_s[0], *outputFileName, write.NovelInfo{Name: *novelName, Author: *novelAuthor})
+	novelInfo := write.NovelInfo{Name: *novelName, Author: *novelAuthor}
+	novelInfo.Complete(metadata)
+	// NOTICE: Cover is not written into .txt, and failing to fetch it is not fatal.
+	if *outputFileFormat != "txt" {
+		if err = novelInfo.FetchCover(); err != nil {
+			fmt.Printf("NOTICE: Fail to fetch cover from %s: %v.\n", novelInfo.CoverURL, err)
+		}
 	}
+	writeOptions := &write.WriteOption{Provenance: *provenance, Chinese: chineseConverter(*chineseScript), Typography: normalizer}
+	err = write.Write(os.Stdout, *outputFileFormat, c_s[0], *outputFileName, novelInfo, writeOptions)
 	if err != nil {
 		log.Fatalf("While writing to file"+errorPrompt, err)
 	}
 }
+
+func validFormat(format string) bool {
+	for _, f := range write.Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// chineseConverters are values of flag `chinese`.
+var chineseConverters = map[string]func() *chinese.Converter{
+	"traditional": chinese.Traditional,
+	"simplified":  chinese.Simplified,
+}
+
+func validChinese(script string) bool {
+	_, ok := chineseConverters[script]
+	return ok || script == ""
+}
+
+// chineseConverter return the Converter to script, or nil if script is empty.
+func chineseConverter(script string) *chinese.Converter {
+	if to, ok := chineseConverters[script]; ok {
+		return to()
+	}
+	return nil
+}
+
+// parseSelection interpret chapter selection flags.
+func parseSelection() (s *extract.Selection, err error) {
+	s = &extract.Selection{From: *chapterFrom, To: *chapterTo, Last: *chapterLast}
+	if s.From < 0 || s.To < 0 || s.Last < 0 || (s.To > 0 && s.From > s.To) {
+		return nil, errInvalidSelection
+	}
+	if *chapterRange != "" {
+		if s.From != 0 || s.To != 0 {
+			return nil, errInvalidSelection
+		}
+		if s.From, s.To, err = extract.ParseRange(*chapterRange); err != nil {
+			return
+		}
+	}
+	if *chapterMatch != "" {
+		if s.Include, err = regexp.Compile(*chapterMatch); err != nil {
+			return
+		}
+	}
+	if *chapterExclude != "" {
+		if s.Exclude, err = regexp.Compile(*chapterExclude); err != nil {
+			return
+		}
+	}
+	return
+}
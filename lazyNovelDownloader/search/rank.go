@@ -0,0 +1,170 @@
+// rank normalize, de-duplicate and order links given by search engines.
+package search
+
+import (
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+// Result is a potential catalogue of the novel.
+type Result struct {
+	Url   string
+	Host  string // Hostname without mirror prefixes like `www.`, `m.`
+	Title string // Text of link given by search engine or novel site
+	Score float64
+	// Latest is the name of the latest chapter listed by novel site. Empty means unknown.
+	Latest string
+}
+
+// Latests collect Latest of results by their Url, skipping unknown ones.
+func Latests(results []Result) map[string]string {
+	rets := make(map[string]string)
+	for _, r := range results {
+		if r.Latest != "" {
+			rets[r.Url] = r.Latest
+		}
+	}
+	return rets
+}
+
+// Urls collect Url of results in order.
+func Urls(results []Result) []string {
+	rets := make([]string, len(results), len(results))
+	for i, r := range results {
+		rets[i] = r.Url
+	}
+	return rets
+}
+
+var (
+	mirrorPrefixes = []string{"www.", "m.", "wap.", "mobile."}
+	indexPages     = []string{"index.html", "index.htm", "index.php", "index.shtml"}
+	// nonCatalogueKeys appear in hosts or paths of forums, reviews and encyclopedias.
+	nonCatalogueKeys = []string{"tieba.", "zhidao.", "baike.", "douban.", "zhihu.", "bbs", "forum", "thread", "review", "comment", "wenda"}
+	// catalogueTitleKeys appear in titles of catalogue pages.
+	catalogueTitleKeys = []string{"目录", "最新章节", "章节列表"}
+)
+
+const (
+	scoreFolder       = 2
+	scoreTitleSimilar = 3
+	scoreCatalogueKey = 1
+	scoreAuthor       = 1
+	scorePathDepth    = -0.1 // per level of path
+)
+
+// hostKey strip mirror prefixes, so that `m.a.com` and `www.a.com` are treated as one host.
+func hostKey(host string) string {
+	host = strings.ToLower(host)
+	for _, prefix := range mirrorPrefixes {
+		if strings.HasPrefix(host, prefix) {
+			return host[len(prefix):]
+		}
+	}
+	return host
+}
+
+// normalizeURL give identity of link, ignoring scheme, mirror prefixes, fragment and index page.
+func normalizeURL(u *url.URL) string {
+	p := strings.TrimSuffix(u.EscapedPath(), "/")
+	for _, index := range indexPages {
+		p = strings.TrimSuffix(p, "/"+index)
+	}
+	ret := hostKey(u.Hostname()) + p
+	if u.RawQuery != "" {
+		ret += "?" + u.RawQuery
+	}
+	return ret
+}
+
+// similarity give ratio of common characters in order, ranging in [0, 1].
+func similarity(u, v string) float64 {
+	var split = func(s string) utils.StringSlices {
+		rets := utils.StringSlices{}
+		for _, r := range s {
+			rets = append(rets, string(r))
+		}
+		return rets
+	}
+	uRunes, vRunes := split(u), split(v)
+	if len(uRunes)+len(vRunes) == 0 {
+		return 0
+	}
+	return 2 * float64(len(utils.IntersectStringSlices(uRunes, vRunes))) / float64(len(uRunes)+len(vRunes))
+}
+
+// isCatalogueURL tells whether the link can be a catalogue.
+// Forums, reviews and pages other than index are excluded, the latter of which are also rejected by `extract.Catalogue`.
+func isCatalogueURL(u *url.URL) bool {
+	lowerURL := strings.ToLower(u.Host + u.Path)
+	for _, key := range nonCatalogueKeys {
+		if strings.Contains(lowerURL, key) {
+			return false
+		}
+	}
+	base := path.Base(u.Path)
+	return strings.ToLower(utils.PageNameURL(base)) == "index" || !strings.Contains(base, ".")
+}
+
+// score evaluate how likely the link is the catalogue of novel.
+func score(u *url.URL, title string, novelName string, author string) (ret float64) {
+	// URL Shape: we prefer folders and shallow paths.
+	if strings.HasSuffix(u.Path, "/") {
+		ret += scoreFolder
+	}
+	ret += scorePathDepth * float64(strings.Count(strings.Trim(u.Path, "/"), "/"))
+	// Title
+	if strings.Contains(title, novelName) {
+		ret += scoreTitleSimilar
+	} else {
+		ret += scoreTitleSimilar * similarity(title, novelName)
+	}
+	for _, key := range catalogueTitleKeys {
+		if strings.Contains(title, key) {
+			ret += scoreCatalogueKey
+		}
+	}
+	if author != "" && strings.Contains(title, author) {
+		ret += scoreAuthor
+	}
+	return
+}
+
+// Rank normalize and de-duplicate tags, keep the best one on each host, and order them by score.
+// Links which are obviously not catalogues are dropped.
+// NOTICE: Redirectors of search engines are kept one by one, since their real hosts are unknown. See `utils.ResolveURL`.
+func Rank(tags []utils.TagA, novelName string, author string) (rets []Result) {
+	exists := make(map[string]bool)
+	best := make(map[string]int) // host -> index in rets
+	for _, tag := range tags {
+		u, err := url.Parse(utils.NormalizeURL(strings.TrimSpace(tag.Href)))
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		u.Fragment = ""
+		id := normalizeURL(u)
+		if exists[id] || !isCatalogueURL(u) {
+			continue
+		}
+		exists[id] = true
+		r := Result{Url: u.String(), Host: hostKey(u.Hostname()), Title: tag.Text, Score: score(u, tag.Text, novelName, author)}
+		key := r.Host
+		if utils.IsRedirectURL(r.Url) {
+			key = id
+		}
+		if i, ok := best[key]; !ok {
+			best[key] = len(rets)
+			rets = append(rets, r)
+		} else if r.Score > rets[i].Score {
+			rets[i] = r
+		}
+	}
+	sort.SliceStable(rets, func(i, j int) bool {
+		return rets[i].Score > rets[j].Score
+	})
+	return
+}
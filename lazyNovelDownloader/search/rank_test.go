@@ -0,0 +1,69 @@
+package search_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/search"
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+func TestRank(t *testing.T) {
+	type Data struct {
+		name  string
+		tags  []utils.TagA
+		hosts []string
+		urls  []string
+	}
+	data := []Data{
+		// hostKey: mirrors of the same host are one host, and the better scored one is kept.
+		Data{"mirrors", []utils.TagA{
+			utils.TagA{Href: "https://m.a.com/book/1.html", Text: "诡秘之主"},
+			utils.TagA{Href: "https://WWW.A.com/book/1/", Text: "诡秘之主最新章节"},
+		}, []string{"a.com"}, []string{"https://WWW.A.com/book/1/"}},
+		// normalizeURL: scheme, fragment, index pages and trailing slashes are ignored.
+		Data{"duplicates", []utils.TagA{
+			utils.TagA{Href: "http://a.com/book/1/index.html#top", Text: "诡秘之主"},
+			utils.TagA{Href: "https://www.a.com/book/1", Text: "诡秘之主"},
+		}, []string{"a.com"}, []string{"http://a.com/book/1/index.html"}},
+		// isCatalogueURL: forums and pages other than index are dropped.
+		Data{"not catalogues", []utils.TagA{
+			utils.TagA{Href: "https://tieba.baidu.com/f?kw=诡秘之主", Text: "诡秘之主吧"},
+			utils.TagA{Href: "https://b.com/forum/1/", Text: "诡秘之主"},
+			utils.TagA{Href: "https://c.com/book/1/2.html", Text: "诡秘之主 第二章"},
+			utils.TagA{Href: "https://d.com/book/1/", Text: "诡秘之主"},
+		}, []string{"d.com"}, []string{"https://d.com/book/1/"}},
+		// score: similar titles, catalogue keys, author, folders and shallow paths go first.
+		Data{"order", []utils.TagA{
+			utils.TagA{Href: "https://a.com/a/b/c/book", Text: "诡秘"},
+			utils.TagA{Href: "https://b.com/a/b/c/book", Text: "诡秘之主"},
+			utils.TagA{Href: "https://c.com/book/", Text: "诡秘之主"},
+			utils.TagA{Href: "https://d.com/book/", Text: "诡秘之主最新章节 爱潜水的乌贼"},
+			utils.TagA{Href: "https://e.com/a/b/c/book/", Text: "诡秘之主"},
+		}, []string{"d.com", "c.com", "e.com", "b.com", "a.com"}, nil},
+		// Redirectors of search engines hide their real hosts.
+		Data{"redirectors", []utils.TagA{
+			utils.TagA{Href: "https://www.baidu.com/link?url=1", Text: "诡秘之主"},
+			utils.TagA{Href: "https://www.baidu.com/link?url=2", Text: "诡秘之主"},
+			utils.TagA{Href: "https://www.baidu.com/link?url=2", Text: "诡秘之主"},
+		}, []string{"baidu.com", "baidu.com"}, []string{"https://www.baidu.com/link?url=1", "https://www.baidu.com/link?url=2"}},
+	}
+	for _, d := range data {
+		rets := search.Rank(d.tags, "诡秘之主", "爱潜水的乌贼")
+		var hosts []string
+		for _, r := range rets {
+			hosts = append(hosts, r.Host)
+		}
+		if !reflect.DeepEqual(hosts, d.hosts) {
+			t.Errorf("Get %v from %v. Expect %v.\n", hosts, d.name, d.hosts)
+		}
+		if urls := search.Urls(rets); d.urls != nil && !reflect.DeepEqual(urls, d.urls) {
+			t.Errorf("Get %v from %v. Expect %v.\n", urls, d.name, d.urls)
+		}
+		for i := 1; i < len(rets); i++ {
+			if rets[i].Score > rets[i-1].Score {
+				t.Errorf("Get %v from %v. Expect ordered by score.\n", rets, d.name)
+			}
+		}
+	}
+}
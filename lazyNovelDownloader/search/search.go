@@ -3,6 +3,7 @@ package search
 import (
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/RaymondJiangkw/Lazy/utils"
 )
@@ -11,29 +12,69 @@ const (
 	searchItemNumbers = 10
 	searchKey         = "目录"
 	searchText        = "Searching for catalogs..."
+	verifyText        = "Verifying catalogs..."
 )
 
-func searchCatalogues(novelName string) ([]utils.TagA, error) {
-	rets, err := utils.Search(&utils.SearchOption{Key: novelName + searchKey, Items: searchItemNumbers})
+func searchCatalogues(novelName string, author string) ([]utils.TagA, error) {
+	key := novelName + searchKey
+	if author != "" {
+		key = novelName + " " + author + " " + searchKey
+	}
+	rets, err := utils.Search(&utils.SearchOption{Key: key, Items: searchItemNumbers})
+	// NOTICE: Novel sites make up the shortage of web search engines, searched by name only.
+	if err != nil {
+		tags, siteErr := utils.ChainProvider(siteProviders(Adapters)).Search(novelName, searchItemNumbers-len(rets))
+		if len(rets) == 0 {
+			err = siteErr
+		}
+		rets = append(rets, tags...)
+	}
 	if err == utils.Shortage && len(rets) == 0 {
 		return nil, utils.Invalid
 	}
 	return rets, err
 }
 
-func Search(writer io.Writer, novelName string) ([]string, error) {
+// resolveRedirects replace redirectors of search engines with their targets concurrently.
+// Tags failing to resolve are dropped.
+func resolveRedirects(tags []utils.TagA) (rets []utils.TagA) {
+	resolved := make([]string, len(tags), len(tags))
+	var wg sync.WaitGroup
+	for i, tag := range tags {
+		wg.Add(1)
+		go func(i int, href string) {
+			defer wg.Done()
+			resolved[i], _ = utils.ResolveURL(href, 0)
+		}(i, tag.Href)
+	}
+	wg.Wait()
+	for i, tag := range tags {
+		if resolved[i] != "" {
+			rets = append(rets, utils.TagA{Href: resolved[i], Text: tag.Text})
+		}
+	}
+	return
+}
+
+// Search find potential catalogues of the novel ordered by score, and exclude pages not mentioning novelName and author.
+// @param author string can be empty, in which case only novelName is used.
+func Search(writer io.Writer, novelName string, author string) ([]Result, error) {
 	var display utils.Display
 	signal := make(chan struct{})
 	finish := display.TemporaryText(writer, searchText, signal)
-	potentialCatalogTags, err := searchCatalogues(novelName)
+	potentialCatalogTags, err := searchCatalogues(novelName, author)
 	signal <- struct{}{}
 	<-finish
 	if err != nil && len(potentialCatalogTags) == 0 {
 		return nil, fmt.Errorf("Not Found Any Catalogues.")
 	}
-	rets := make([]string, len(potentialCatalogTags), len(potentialCatalogTags))
-	for i, tag := range potentialCatalogTags {
-		rets[i] = tag.Href
+	signal = make(chan struct{})
+	finish = display.TemporaryText(writer, verifyText, signal)
+	rets := verifyCatalogues(Rank(resolveRedirects(potentialCatalogTags), novelName, author), novelName, author)
+	signal <- struct{}{}
+	<-finish
+	if len(rets) == 0 {
+		return nil, fmt.Errorf("Not Found Any Catalogues of %s.", novelName)
 	}
 	return rets, nil
 }
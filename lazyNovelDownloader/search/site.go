@@ -0,0 +1,198 @@
+// site search books on novel sites directly, instead of web search engines.
+// Rules here depend on layouts of sites, which may change from time to time.
+package search
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+	"golang.org/x/net/html"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+const (
+	siteSearchText = "Searching novel sites..."
+)
+
+// Book is an entry in the result list of a novel site.
+type Book struct {
+	Title  string
+	Author string
+	Latest string // Name of the latest chapter
+	Url    string // URL of catalogue
+	Site   string // Name of adapter
+}
+
+// SiteAdapter submit query to the search form of a novel site by `utils.SiteSearchProvider`, and parse its result list.
+// Selectors of Title, Author and Latest are relative to each Item.
+// NOTICE: The embedded provider selects only links of titles, which chains the site after web search engines. See `searchCatalogues`.
+type SiteAdapter struct {
+	*utils.SiteSearchProvider
+	Item   string
+	Title  string // <a> linking to catalogue
+	Author string
+	Latest string
+}
+
+// newSiteAdapter create adapter whose provider selects Title under Item.
+func newSiteAdapter(name string, action string, queryKey string, e encoding.Encoding, item string, title string, author string, latest string) *SiteAdapter {
+	return &SiteAdapter{
+		SiteSearchProvider: &utils.SiteSearchProvider{Title: name, Action: action, QueryKey: queryKey, Encoding: e, Selector: item + " " + title},
+		Item:               item,
+		Title:              title,
+		Author:             author,
+		Latest:             latest,
+	}
+}
+
+// authorPrefixes are stripped from text of author.
+var authorPrefixes = []string{"作者：", "作者:", "作者"}
+
+func firstText(root *html.Node, sel string) string {
+	if sel == "" {
+		return ""
+	}
+	nodes := utils.Select(root, sel)
+	if len(nodes) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(utils.ExtractText(nodes[0], "", nil))
+}
+
+// Parse extract books from result page.
+// @param base string URL of result page, used to complete relative links.
+func (a *SiteAdapter) Parse(base string, body *string) (rets []Book, err error) {
+	doc, err := html.Parse(strings.NewReader(*body))
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range utils.Select(doc, a.Item) {
+		tags := utils.ParseATags(utils.Select(item, a.Title))
+		if len(tags) == 0 || tags[0].Text == "" {
+			continue
+		}
+		url, err := utils.CompleteURL(base, tags[0].Href)
+		if err != nil {
+			continue
+		}
+		author := firstText(item, a.Author)
+		for _, prefix := range authorPrefixes {
+			author = strings.TrimSpace(strings.TrimPrefix(author, prefix))
+		}
+		rets = append(rets, Book{Title: tags[0].Text, Author: author, Latest: firstText(item, a.Latest), Url: url, Site: a.Name()})
+	}
+	return rets, nil
+}
+
+// Search submit key to the site and parse the result page.
+func (a *SiteAdapter) Search(key string) ([]Book, error) {
+	URL, err := a.URL(key)
+	if err != nil {
+		return nil, err
+	}
+	bodies, errs, ioCompletes := utils.Fetch([]string{URL}, &utils.FetchOption{Refresh: true, Redirect: true, UseCookie: true})
+	defer utils.WaitSync(ioCompletes)
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+	return a.Parse(URL, bodies[0])
+}
+
+// NewResultListAdapter create adapter for sites listing results in `.result-list`, which is common among `biquge` mirrors.
+func NewResultListAdapter(name string, action string, queryKey string, e encoding.Encoding) *SiteAdapter {
+	return newSiteAdapter(name, action, queryKey, e,
+		".result-list .result-item",
+		".result-game-item-title a",
+		".result-game-item-info p:nth-of-type(1) span:nth-of-type(2)",
+		".result-game-item-info p:nth-of-type(4) a")
+}
+
+// NewGridTableAdapter create adapter for sites listing results in `table.grid`, which is common among sites built on `jieqi`.
+func NewGridTableAdapter(name string, action string, queryKey string, e encoding.Encoding) *SiteAdapter {
+	return newSiteAdapter(name, action, queryKey, e,
+		"table.grid tr",
+		"td:nth-of-type(1) a",
+		"td:nth-of-type(3)",
+		"td:nth-of-type(2) a")
+}
+
+// Adapters are novel sites searched by `SearchSites`, and by `Search` after web search engines.
+var Adapters = []*SiteAdapter{
+	NewResultListAdapter("biquge", "https://www.biquge.com.cn/search.php", "q", nil),
+	NewGridTableAdapter("xbiquge", "https://www.xbiquge.so/modules/article/search.php", "searchkey", simplifiedchinese.GBK),
+}
+
+// siteProviders give providers of adapters, to be chained by `utils.ChainProvider`.
+func siteProviders(adapters []*SiteAdapter) []utils.SearchProvider {
+	rets := make([]utils.SearchProvider, len(adapters), len(adapters))
+	for i, a := range adapters {
+		rets[i] = a.SiteSearchProvider
+	}
+	return rets
+}
+
+// matchBook tells whether book is the novel. Empty author always matches.
+func matchBook(book Book, novelName string, author string) bool {
+	if strings.TrimSpace(book.Title) != novelName {
+		return false
+	}
+	return author == "" || book.Author == "" || strings.Contains(book.Author, author)
+}
+
+// SearchSites search novelName on every adapter concurrently, and return catalogues of matched books.
+// Results are ordered by score, one for each host.
+// @param author string can be empty, in which case only novelName is used.
+func SearchSites(writer io.Writer, novelName string, author string, adapters []*SiteAdapter) ([]Result, error) {
+	var display utils.Display
+	if adapters == nil {
+		adapters = Adapters
+	}
+	signal := make(chan struct{})
+	finish := display.TemporaryText(writer, siteSearchText, signal)
+	results := make([][]Book, len(adapters), len(adapters))
+	errs := make([]error, len(adapters), len(adapters))
+	var wg sync.WaitGroup
+	for i, adapter := range adapters {
+		wg.Add(1)
+		go func(i int, adapter *SiteAdapter) {
+			defer wg.Done()
+			results[i], errs[i] = adapter.Search(novelName)
+		}(i, adapter)
+	}
+	wg.Wait()
+	signal <- struct{}{}
+	<-finish
+
+	var tags []utils.TagA
+	latest := make(map[string]string)
+	for _, books := range results {
+		for _, book := range books {
+			if matchBook(book, novelName, author) {
+				tags = append(tags, utils.TagA{Href: book.Url, Text: book.Title})
+				latest[book.Url] = book.Latest
+			}
+		}
+	}
+	rets := Rank(tags, novelName, author)
+	if len(rets) == 0 {
+		// NOTICE: Failures of sites tell why nothing is found, e.g. network or changed layouts.
+		var failures []string
+		for i, err := range errs {
+			if err != nil {
+				failures = append(failures, adapters[i].Name()+": "+err.Error())
+			}
+		}
+		if len(failures) > 0 {
+			return nil, fmt.Errorf("Not Found Any Catalogues of %s. %s", novelName, strings.Join(failures, "; "))
+		}
+		return nil, fmt.Errorf("Not Found Any Catalogues of %s.", novelName)
+	}
+	for i := range rets {
+		rets[i].Latest = latest[rets[i].Url]
+	}
+	return rets, nil
+}
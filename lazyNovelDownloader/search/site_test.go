@@ -0,0 +1,151 @@
+package search_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/search"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestSiteAdapterParse(t *testing.T) {
+	type Data struct {
+		adapter *search.SiteAdapter
+		base    string
+		fixture string
+		result  []search.Book
+	}
+	data := []Data{
+		Data{
+			adapter: search.NewResultListAdapter("list", "https://www.example.com/search.php", "q", nil),
+			base:    "https://www.example.com/search.php?q=test",
+			fixture: "result_list.html",
+			result: []search.Book{
+				search.Book{Title: "诡秘之主", Author: "爱潜水的乌贼", Latest: "完本感言", Url: "https://www.example.com/book/1234/", Site: "list"},
+				search.Book{Title: "诡秘之主同人", Author: "路人甲", Latest: "第一章", Url: "https://m.example.com/book/99/", Site: "list"},
+			},
+		},
+		Data{
+			adapter: search.NewGridTableAdapter("grid", "https://www.example.org/modules/article/search.php", "searchkey", nil),
+			base:    "https://www.example.org/modules/article/search.php?searchkey=test",
+			fixture: "grid_table.html",
+			result: []search.Book{
+				search.Book{Title: "诡秘之主", Author: "爱潜水的乌贼", Latest: "第一千三百九十四章 新的道路", Url: "https://www.example.org/0_123/", Site: "grid"},
+				search.Book{Title: "诡秘之主之序列", Author: "某某", Latest: "第五章 开始", Url: "https://www.example.org/0_789/", Site: "grid"},
+			},
+		},
+	}
+	for _, d := range data {
+		raw, err := ioutil.ReadFile(filepath.Join("testdata", d.fixture))
+		if err != nil {
+			t.Fatalf("Read fixture %s: %v\n", d.fixture, err)
+		}
+		body := string(raw)
+		books, err := d.adapter.Parse(d.base, &body)
+		if err != nil {
+			t.Errorf("Parse %s: %v\n", d.fixture, err)
+			continue
+		}
+		if len(books) != len(d.result) {
+			t.Errorf("Get %v from %s. Expect %v.\n", books, d.fixture, d.result)
+			continue
+		}
+		for i := range books {
+			if books[i] != d.result[i] {
+				t.Errorf("Get %v from %s. Expect %v.\n", books[i], d.fixture, d.result[i])
+			}
+		}
+	}
+}
+
+func TestSiteAdapterURL(t *testing.T) {
+	a := search.NewGridTableAdapter("grid", "https://www.example.org/modules/article/search.php", "searchkey", simplifiedchinese.GBK)
+	a.Params = map[string]string{"type": "articlename", "action": "login"}
+	expect := "https://www.example.org/modules/article/search.php?action=login&searchkey=%B9%EE%C3%D8&type=articlename"
+	if URL, err := a.URL("诡秘"); err != nil || URL != expect {
+		t.Errorf("Get %v, %v. Expect %v.\n", URL, err, expect)
+	}
+}
+
+// inTempDir run tests in a temporary directory, so that cache of `utils.Fetch` is not left in the tree.
+func inTempDir(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// TestSiteSearchProvider check that the provider chained after web search engines selects the same catalogues as `Parse`.
+func TestSiteSearchProvider(t *testing.T) {
+	testdata, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inTempDir(t)
+	for _, fixture := range []string{"result_list.html", "grid_table.html"} {
+		raw, err := ioutil.ReadFile(filepath.Join(testdata, fixture))
+		if err != nil {
+			t.Fatalf("Read fixture %s: %v\n", fixture, err)
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write(raw) }))
+		defer server.Close()
+		a := search.NewResultListAdapter("list", server.URL+"/search.php", "q", nil)
+		if fixture == "grid_table.html" {
+			a = search.NewGridTableAdapter("grid", server.URL+"/search.php", "q", nil)
+		}
+		body := string(raw)
+		books, _ := a.Parse(server.URL+"/search.php", &body)
+		tags, err := a.SiteSearchProvider.Search("诡秘之主", len(books))
+		if err != nil || len(tags) != len(books) {
+			t.Errorf("Get %v, %v from %s. Expect %v.\n", tags, err, fixture, books)
+			continue
+		}
+		for i := range tags {
+			if tags[i].Href != books[i].Url || tags[i].Text != books[i].Title {
+				t.Errorf("Get %v from %s. Expect %v.\n", tags[i], fixture, books[i])
+			}
+		}
+	}
+}
+
+func TestSearchSites(t *testing.T) {
+	testdata, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inTempDir(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, filepath.Join(testdata, r.URL.Path[1:]))
+	}))
+	defer server.Close()
+	adapters := []*search.SiteAdapter{
+		search.NewResultListAdapter("list", server.URL+"/result_list.html", "q", nil),
+		search.NewGridTableAdapter("grid", server.URL+"/grid_table.html", "searchkey", nil),
+	}
+	rets, err := search.SearchSites(ioutil.Discard, "诡秘之主", "爱潜水的乌贼", adapters)
+	if err != nil {
+		t.Fatalf("Get %v. Expect %v.\n", err, nil)
+	}
+	expects := map[string]string{
+		server.URL + "/book/1234/":       "完本感言",
+		"https://www.example.org/0_123/": "第一千三百九十四章 新的道路",
+	}
+	if latest := search.Latests(rets); !reflect.DeepEqual(latest, expects) {
+		t.Errorf("Get %v. Expect %v.\n", latest, expects)
+	}
+	// Failures of sites are reported when nothing is found.
+	adapters = []*search.SiteAdapter{search.NewResultListAdapter("broken", "://search.php", "q", nil)}
+	if _, err := search.SearchSites(ioutil.Discard, "诡秘之主", "", adapters); err == nil || !strings.Contains(err.Error(), "broken: ") {
+		t.Errorf("Get %v. Expect failure of broken.\n", err)
+	}
+}
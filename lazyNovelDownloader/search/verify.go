@@ -0,0 +1,76 @@
+// verify check whether a catalogue page belongs to the novel.
+package search
+
+import (
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+	"golang.org/x/net/html"
+)
+
+var (
+	nameMetaKeys = []string{"og:novel:book_name", "og:title", "keywords", "description"}
+	// NOTICE: The generic `author` is often the name of site.
+	authorMetaKeys = []string{"og:novel:author"}
+)
+
+// MatchNovel tells whether the page is about novelName written by author.
+// Novel name is looked up in <title> and meta tags first, then in the whole text.
+// Author is compared with `og:novel:author` if exists, otherwise looked up in the whole text.
+// Empty author always matches.
+func MatchNovel(body *string, novelName string, author string) bool {
+	doc, err := html.Parse(strings.NewReader(*body))
+	if err != nil {
+		return false
+	}
+	metas := utils.ParseMetaTags(doc)
+	var text *string
+	var wholeText = func() string {
+		if text == nil {
+			t := utils.ExtractText(doc, "", nil)
+			text = &t
+		}
+		return *text
+	}
+
+	nameFound := false
+	for _, title := range utils.Select(doc, "title") {
+		if strings.Contains(utils.ExtractText(title, "", nil), novelName) {
+			nameFound = true
+		}
+	}
+	for _, key := range nameMetaKeys {
+		if strings.Contains(metas[key], novelName) {
+			nameFound = true
+		}
+	}
+	if !nameFound && !strings.Contains(wholeText(), novelName) {
+		return false
+	}
+
+	if author == "" {
+		return true
+	}
+	for _, key := range authorMetaKeys {
+		if v, ok := metas[key]; ok && v != "" {
+			return strings.Contains(v, author)
+		}
+	}
+	return strings.Contains(wholeText(), author)
+}
+
+// verifyCatalogues exclude results whose pages do not belong to the novel. Order is kept.
+// Pages are cached, so that `extract.InspectCachedCatalogue` needs not fetch them again.
+func verifyCatalogues(results []Result, novelName string, author string) (rets []Result) {
+	bodies, errs, ioCompletes := utils.Fetch(Urls(results), &utils.FetchOption{Redirect: true, Refresh: true})
+	defer utils.WaitSync(ioCompletes)
+	for i, r := range results {
+		if errs[i] != nil {
+			continue
+		}
+		if MatchNovel(bodies[i], novelName, author) {
+			rets = append(rets, r)
+		}
+	}
+	return
+}
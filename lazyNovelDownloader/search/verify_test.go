@@ -0,0 +1,32 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/search"
+)
+
+func TestMatchNovel(t *testing.T) {
+	type Data struct {
+		page   string
+		name   string
+		author string
+		expect bool
+	}
+	data := []Data{
+		Data{`<html><head><title>诡秘之主最新章节</title></head><body></body></html>`, "诡秘之主", "", true},
+		Data{`<html><head><meta name="keywords" content="诡秘之主,爱潜水的乌贼"></head><body></body></html>`, "诡秘之主", "", true},
+		Data{`<html><body><h1>诡秘之主</h1></body></html>`, "诡秘之主", "", true},
+		Data{`<html><head><title>全职高手</title></head><body></body></html>`, "诡秘之主", "", false},
+		Data{`<html><head><title>诡秘之主</title><meta property="og:novel:author" content="爱潜水的乌贼"></head><body></body></html>`, "诡秘之主", "爱潜水的乌贼", true},
+		Data{`<html><head><title>诡秘之主</title><meta property="og:novel:author" content="某某"></head><body>爱潜水的乌贼</body></html>`, "诡秘之主", "爱潜水的乌贼", false},
+		// The generic `author` is the name of site.
+		Data{`<html><head><title>诡秘之主</title><meta name="author" content="笔趣阁"></head><body>作者：爱潜水的乌贼</body></html>`, "诡秘之主", "爱潜水的乌贼", true},
+		Data{`<html><head><title>诡秘之主</title></head><body>作者：某某</body></html>`, "诡秘之主", "爱潜水的乌贼", false},
+	}
+	for _, d := range data {
+		if ret := search.MatchNovel(&d.page, d.name, d.author); ret != d.expect {
+			t.Errorf("Get %v from %v. Expect %v.\n", ret, d.page, d.expect)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// typography normalize punctuations, quotes, ellipses, dashes and broken lines of contents before writing.
+package typography
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+const (
+	textPrefix = "    "
+	// indentRunes indent paragraphs, and are trimmed before normalizing.
+	indentRunes = " \t\u00a0\u3000"
+	// endingRunes end sentences, after which lines are never merged.
+	endingRunes = "。！？!?…”」』）)—~～\"'：:"
+	// continuingRunes are inside sentences. Lines ending with them are broken.
+	continuingRunes = "，、；,;"
+	dashRunes       = "-－—―"
+	// minimumWrapWidth is the least width of lines wrapped by sites, below which lines are perceived as paragraphs.
+	minimumWrapWidth = 8
+	// wrapRatio of width, above which lines ending with characters are cut by wrapping.
+	wrapRatio = 0.9
+)
+
+var (
+	ellipsisPattern = regexp.MustCompile(`\.{3,}|。{3,}|．{3,}|[·・]{3,}|…+`)
+	dashPattern     = regexp.MustCompile(`-{2,}|－{2,}|[—―]+`)
+	// fullWidth are full-width forms of half-width punctuations.
+	fullWidth = map[rune]rune{',': '，', '.': '。', '!': '！', '?': '？', ':': '：', ';': '；', '(': '（', ')': '）'}
+	halfWidth = map[rune]rune{'，': ',', '。': '.', '！': '!', '？': '?', '：': ':', '；': ';', '（': '(', '）': ')'}
+)
+
+// Width tells the width of punctuations.
+type Width int
+
+const (
+	KeepWidth Width = iota
+	// FullWidth turn punctuations next to CJK characters into full-width, e.g. `你好,世界` to `你好，世界`,
+	// and full-width letters and digits into half-width.
+	FullWidth
+	// HalfWidth turn full-width punctuations, letters and digits into half-width.
+	HalfWidth
+)
+
+// Quotes tells the style of quotes.
+type Quotes int
+
+const (
+	KeepQuotes Quotes = iota
+	CurlyQuotes
+	CornerQuotes
+)
+
+// quoteForms are opening and closing double quotes, and then single quotes, of every style.
+var quoteForms = map[Quotes][4]rune{
+	CurlyQuotes:  [4]rune{'“', '”', '‘', '’'},
+	CornerQuotes: [4]rune{'「', '」', '『', '』'},
+}
+
+// Normalizer normalize typography of texts. Its zero value keeps texts unchanged except for indentation and blank lines.
+type Normalizer struct {
+	Width  Width
+	Quotes Quotes
+	// MergeLines join lines broken inside sentences, e.g. by `<br>` in the middle of a paragraph or by wrapping. See `broken`.
+	MergeLines bool
+	// Ellipses turn `...`, `。。。` and `…` into `……`.
+	Ellipses bool
+	// Dashes turn `--`, `—` and `―` into `——`.
+	Dashes bool
+}
+
+// NewNormalizer create Normalizer for Chinese texts, which enables everything with full-width punctuations and curly quotes.
+func NewNormalizer() *Normalizer {
+	return &Normalizer{Width: FullWidth, Quotes: CurlyQuotes, MergeLines: true, Ellipses: true, Dashes: true}
+}
+
+// Parse create Normalizer from comma-separated items, e.g. `full,curly,merge`.
+// Items are `full`/`half` for Width, `curly`/`corner` for Quotes, `merge`, `ellipsis` and `dash`. `default` means `NewNormalizer`.
+// nil is returned for empty spec.
+func Parse(spec string) (*Normalizer, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	n := &Normalizer{}
+	for _, item := range strings.Split(spec, ",") {
+		switch strings.TrimSpace(item) {
+		case "default":
+			*n = *NewNormalizer()
+		case "full":
+			n.Width = FullWidth
+		case "half":
+			n.Width = HalfWidth
+		case "curly":
+			n.Quotes = CurlyQuotes
+		case "corner":
+			n.Quotes = CornerQuotes
+		case "merge":
+			n.MergeLines = true
+		case "ellipsis":
+			n.Ellipses = true
+		case "dash":
+			n.Dashes = true
+		default:
+			return nil, utils.Invalid
+		}
+	}
+	return n, nil
+}
+
+// Line normalize a single line, e.g. name of chapter.
+func (n *Normalizer) Line(s string) string {
+	if n == nil {
+		return s
+	}
+	return n.quote(n.punctuate(trim(s)))
+}
+
+// Content normalize content of chapter, whose paragraphs are indented lines.
+// Blank lines are removed, and every paragraph is indented by four spaces.
+func (n *Normalizer) Content(content string) string {
+	if n == nil {
+		return content
+	}
+	var raw []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = n.punctuate(trim(line)); line != "" {
+			raw = append(raw, line)
+		}
+	}
+	width := wrapWidth(raw)
+	var lines []string
+	for i, line := range raw {
+		if n.MergeLines && i > 0 && broken(raw[i-1], line, width) {
+			lines[len(lines)-1] += line
+			continue
+		}
+		lines = append(lines, line)
+	}
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(textPrefix + n.quote(line) + "\n")
+	}
+	return b.String()
+}
+
+// trim turn non-breaking spaces, including the escaped ones left by sites, into spaces, and trim indentation.
+func trim(s string) string {
+	s = strings.ReplaceAll(s, "&nbsp;", " ")
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	return strings.Trim(s, indentRunes)
+}
+
+// broken tells whether next continues the sentence of line.
+// Lines ending with characters are broken only if they are cut at width, since paragraphs, e.g. dialogues, may have no ending punctuations.
+// @param width int width of wrapping given by `wrapWidth`. 0 means lines are not wrapped.
+func broken(line, next string, width int) bool {
+	last, first := lastRune(line), []rune(next)[0]
+	if !unicode.Is(unicode.Han, first) && !strings.ContainsRune("，。、；！？…", first) {
+		return false
+	}
+	if strings.ContainsRune(continuingRunes, last) {
+		return true
+	}
+	return unicode.Is(unicode.Han, last) && width > 0 && float64(utf8.RuneCountInString(line)) >= wrapRatio*float64(width)
+}
+
+// wrapWidth give the width of lines wrapped by sites, which is the most common length of long lines. 0 means no wrapping.
+func wrapWidth(lines []string) (width int) {
+	counts := make(map[int]int)
+	for _, line := range lines {
+		if l := utf8.RuneCountInString(line); l >= minimumWrapWidth {
+			counts[l]++
+		}
+	}
+	for l, count := range counts {
+		if count >= 2 && (count > counts[width] || (count == counts[width] && l > width)) {
+			width = l
+		}
+	}
+	return
+}
+
+func lastRune(s string) rune {
+	runes := []rune(s)
+	return runes[len(runes)-1]
+}
+
+// isCJK tells whether r is a CJK character or punctuation.
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || (r >= 0x3000 && r <= 0x303F) || (r >= 0xFF01 && r <= 0xFF5E) || strings.ContainsRune("“”‘’…—", r)
+}
+
+// punctuate normalize ellipses, dashes and width of punctuations in line.
+func (n *Normalizer) punctuate(line string) string {
+	if n.Ellipses {
+		line = ellipsisPattern.ReplaceAllString(line, "……")
+	}
+	// NOTICE: Lines of dashes only are separators.
+	if n.Dashes && strings.Trim(line, dashRunes) != "" {
+		line = dashPattern.ReplaceAllString(line, "——")
+	}
+	switch n.Width {
+	case FullWidth:
+		return toFullWidth(line)
+	case HalfWidth:
+		return strings.Map(func(r rune) rune {
+			if h, ok := halfWidth[r]; ok {
+				return h
+			}
+			return halfWidthAlnum(r)
+		}, line)
+	}
+	return line
+}
+
+func halfWidthAlnum(r rune) rune {
+	if (r >= '０' && r <= '９') || (r >= 'Ａ' && r <= 'Ｚ') || (r >= 'ａ' && r <= 'ｚ') {
+		return r - '０' + '0'
+	}
+	return r
+}
+
+// toFullWidth turn half-width punctuations next to CJK characters into full-width, together with spaces around removed.
+// NOTICE: `.` becomes `。` only after CJK characters and not right before letters, digits or another `.`,
+// so that `3.14`, `.NET` and `...` are kept.
+func toFullWidth(line string) string {
+	runes := []rune(line)
+	rets := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := halfWidthAlnum(runes[i])
+		f, ok := fullWidth[r]
+		if !ok {
+			rets = append(rets, r)
+			continue
+		}
+		j, k := len(rets)-1, i+1
+		for j >= 0 && rets[j] == ' ' {
+			j--
+		}
+		for k < len(runes) && runes[k] == ' ' {
+			k++
+		}
+		before, after := j >= 0 && isCJK(rets[j]), k < len(runes) && isCJK(runes[k])
+		if (!before && (r == '.' || !after)) || (r == '.' && i+1 < len(runes) && strings.ContainsRune(".0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ", runes[i+1])) {
+			rets = append(rets, r)
+			continue
+		}
+		rets = append(rets[:j+1], f)
+		i = k - 1
+	}
+	return string(rets)
+}
+
+// quote pair quotes in line alternately, so that `“你好“` becomes `“你好”`, in the style of n.
+// Apostrophes between letters, e.g. `don't`, are kept.
+func (n *Normalizer) quote(line string) string {
+	forms, ok := quoteForms[n.Quotes]
+	if !ok {
+		return line
+	}
+	runes := []rune(line)
+	var double, single int
+	for i, r := range runes {
+		switch r {
+		case '"', '＂', '“', '”', '「', '」':
+			runes[i] = forms[double%2]
+			double++
+		case '‘', '’', '『', '』':
+			if r == '’' && i > 0 && i+1 < len(runes) && unicode.IsLetter(runes[i-1]) && !isCJK(runes[i-1]) && unicode.IsLetter(runes[i+1]) {
+				continue
+			}
+			runes[i] = forms[2+single%2]
+			single++
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,71 @@
+package typography_test
+
+import (
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/typography"
+)
+
+func TestLine(t *testing.T) {
+	type Data struct {
+		normalizer *typography.Normalizer
+		s          string
+		expect     string
+	}
+	full, corner := typography.NewNormalizer(), typography.NewNormalizer()
+	corner.Quotes = typography.CornerQuotes
+	half := &typography.Normalizer{Width: typography.HalfWidth}
+	data := []Data{
+		Data{full, "他说 , 你好!世界.", "他说，你好！世界。"},
+		Data{full, "版本3.14 , 基于.NET (测试)", "版本3.14，基于.NET（测试）"},
+		Data{full, "ＡＢＣ１２３。", "ABC123。"},
+		Data{full, "他说：“你好“。", "他说：“你好”。"},
+		Data{full, "等等...还有。。。以及…", "等等……还有……以及……"},
+		Data{full, "突然--砰—", "突然——砰——"},
+		Data{full, "it’s “fine”", "it’s “fine”"},
+		Data{corner, "他说：“你好，‘朋友’。”", "他说：「你好，『朋友』。」"},
+		Data{half, "你好，世界！（ＯＫ）", "你好,世界!(OK)"},
+		Data{nil, "不变 ,", "不变 ,"},
+	}
+	for _, d := range data {
+		if ret := d.normalizer.Line(d.s); ret != d.expect {
+			t.Errorf("Get %v from %v. Expect %v.\n", ret, d.s, d.expect)
+		}
+	}
+}
+
+func TestContent(t *testing.T) {
+	type Data struct {
+		content string
+		expect  string
+	}
+	data := []Data{
+		Data{"    他站在窗前，\n\n　　看着外面的雨。\n&nbsp;&nbsp;“下雨了。”\n    他说：\n    “走吧。”\n    ————————\n", "    他站在窗前，看着外面的雨。\n    “下雨了。”\n    他说：\n    “走吧。”\n    ————————\n"},
+		// Lines cut by wrapping at the same width.
+		Data{"    克莱恩推开门走进了冰冷的夜色\n街道两侧的煤气路灯散发着昏黄\n的光芒。\n", "    克莱恩推开门走进了冰冷的夜色街道两侧的煤气路灯散发着昏黄的光芒。\n"},
+		// Short paragraphs without ending punctuations are kept.
+		Data{"    第二天清晨\n    他醒了过来\n", "    第二天清晨\n    他醒了过来\n"},
+		Data{"    克莱恩推开门走进了冰冷的夜色\n    街道两侧的煤气路灯散发着昏黄的光芒。\n    好冷\n    他说\n", "    克莱恩推开门走进了冰冷的夜色\n    街道两侧的煤气路灯散发着昏黄的光芒。\n    好冷\n    他说\n"},
+	}
+	for _, d := range data {
+		if ret := typography.NewNormalizer().Content(d.content); ret != d.expect {
+			t.Errorf("Get %q from %q. Expect %q.\n", ret, d.content, d.expect)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	n, err := typography.Parse("half, corner,merge")
+	if err != nil || *n != (typography.Normalizer{Width: typography.HalfWidth, Quotes: typography.CornerQuotes, MergeLines: true}) {
+		t.Errorf("Get %v, %v. Expect half, corner and merge.\n", n, err)
+	}
+	if n, err = typography.Parse("default"); err != nil || *n != *typography.NewNormalizer() {
+		t.Errorf("Get %v, %v. Expect %v.\n", n, err, typography.NewNormalizer())
+	}
+	if n, err = typography.Parse(""); n != nil || err != nil {
+		t.Errorf("Get %v, %v. Expect nil.\n", n, err)
+	}
+	if _, err = typography.Parse("bold"); err == nil {
+		t.Errorf("Get nil error from unknown item.\n")
+	}
+}
@@ -0,0 +1,82 @@
+// image download images in contents and embed them into .epub, while .txt keeps their placeholders as links.
+package write
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/utils"
+
+	"github.com/bmaupin/go-epub"
+)
+
+const (
+	imageName = "image"
+)
+
+// addImages download images in contents of fetched chapters, and add them to e.
+// Images failing to download, or not of known types, e.g. error pages, are skipped and written as links.
+// @return images map[string]string paths of images in .epub by their urls.
+// @return files []string temporary files of images, which should be removed after writing .epub.
+func addImages(e *epub.Epub, chapters extract.Chapters) (images map[string]string, files []string, err error) {
+	images = make(map[string]string)
+	var urls []string
+	seen := make(map[string]bool)
+	for _, c := range chapters {
+		if !c.Fetch {
+			continue
+		}
+		for _, url := range extract.Images(c.Content) {
+			if !seen[url] {
+				seen[url] = true
+				urls = append(urls, url)
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+	fmt.Printf("Fetching %d images...\n", len(urls))
+	bodies, errs, ioCompletes := utils.Fetch(urls, &utils.FetchOption{Binary: true})
+	defer utils.WaitSync(ioCompletes)
+	for i, url := range urls {
+		if errs[i] != nil {
+			continue
+		}
+		image := []byte(*bodies[i])
+		if _, ok := imageExtensions[http.DetectContentType(image)]; !ok {
+			continue
+		}
+		filePath, ext, err := writeImageFile(image, imageName)
+		if err != nil {
+			return images, files, err
+		}
+		files = append(files, filePath)
+		if images[url], err = e.AddImage(filePath, imageName+"-"+strconv.Itoa(len(files))+ext); err != nil {
+			return images, files, err
+		}
+	}
+	return
+}
+
+// epubImages turn placeholders in content into <img> of images added, or links to the others.
+// NOTICE: url is kept in `data-source`, so that the placeholder can be read back. See `ParseEpubChapter`.
+func epubImages(content string, images map[string]string) string {
+	return extract.ReplaceImages(content, func(url string) string {
+		if path, ok := images[url]; ok {
+			return `<img src="` + path + `" alt="" data-source="` + html.EscapeString(url) + `" />`
+		}
+		return `<a href="` + html.EscapeString(url) + `">` + html.EscapeString(extract.ImageText(url)) + `</a>`
+	})
+}
+
+// removeFiles remove temporary files, ignoring errors.
+func removeFiles(files []string) {
+	for _, f := range files {
+		os.Remove(f)
+	}
+}
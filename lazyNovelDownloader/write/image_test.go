@@ -0,0 +1,37 @@
+package write_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/chinese"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/typography"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/write"
+)
+
+func TestWriteImages(t *testing.T) {
+	urls := []string{"https://example.com/插图/1--2.jpg", "https://example.com/a,b...c.png"}
+	content := "    他说,\"看这里\"" + extract.ImageText(urls[0]) + "\n    " + extract.ImageText(urls[1]) + "\n"
+	chapters := extract.Chapters{&extract.Chapter{Name: "第一章 插图", Content: content, Fetch: true}}
+	filePath := filepath.Join(t.TempDir(), "novel")
+	options := &write.WriteOption{Chinese: chinese.Traditional(), Typography: typography.NewNormalizer()}
+	if err := write.Write(ioutil.Discard, "txt", chapters, filePath, write.NovelInfo{Name: "诡秘之主"}, options); err != nil {
+		t.Fatalf("Get %v while writing.\n", err)
+	}
+	c, _, err := write.Read(filePath + ".txt")
+	if err != nil {
+		t.Fatalf("Get %v while reading.\n", err)
+	}
+	if len(c) != 1 {
+		t.Fatalf("Get %v chapters. Expect 1.\n", len(c))
+	}
+	if got := extract.Images(c[0].Content); !reflect.DeepEqual(got, urls) {
+		t.Errorf("Get %v from %q. Expect %v.\n", got, c[0].Content, urls)
+	}
+	if expect := "    他說，“看這裡”" + extract.ImageText(urls[0]) + "\n    " + extract.ImageText(urls[1]) + "\n"; c[0].Content != expect {
+		t.Errorf("Get %q. Expect %q.\n", c[0].Content, expect)
+	}
+}
@@ -0,0 +1,194 @@
+// info write information of novel, e.g. cover and synopsis, into headers of .txt and metadata of .epub.
+package write
+
+import (
+	"archive/zip"
+	"html"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+const (
+	tagSeparator  = ", "
+	infoSeparator = ": "
+	infoFile      = "info.xhtml"
+	coverName     = "cover"
+	genreID       = "genre"
+	statusMeta    = "status"
+)
+
+// imageExtensions are extensions of images by their content types.
+var imageExtensions = map[string]string{"image/jpeg": ".jpg", "image/png": ".png", "image/gif": ".gif", "image/webp": ".webp"}
+
+// infoField is an optional field of NovelInfo, written after Name and Author.
+type infoField struct {
+	label string
+	get   func(n *NovelInfo) string
+	set   func(n *NovelInfo, v string)
+}
+
+var infoFields = []infoField{
+	{"Genre", func(n *NovelInfo) string { return n.Genre }, func(n *NovelInfo, v string) { n.Genre = v }},
+	{"Tags", func(n *NovelInfo) string { return strings.Join(n.Tags, tagSeparator) }, func(n *NovelInfo, v string) { n.Tags = strings.Split(v, tagSeparator) }},
+	{"Status", func(n *NovelInfo) string { return n.Status }, func(n *NovelInfo, v string) { n.Status = v }},
+	{"Source", func(n *NovelInfo) string { return n.Source }, func(n *NovelInfo, v string) { n.Source = v }},
+	{"Cover", func(n *NovelInfo) string { return n.CoverURL }, func(n *NovelInfo, v string) { n.CoverURL = v }},
+	// NOTICE: Synopsis is written in one line.
+	{"Synopsis", func(n *NovelInfo) string { return strings.Join(strings.Fields(n.Synopsis), " ") }, func(n *NovelInfo, v string) { n.Synopsis = v }},
+}
+
+// lookupInfoField find field by label, which is case-insensitive.
+func lookupInfoField(label string) (infoField, bool) {
+	for _, f := range infoFields {
+		if strings.EqualFold(f.label, label) {
+			return f, true
+		}
+	}
+	return infoField{}, false
+}
+
+// Complete fill empty fields by metadata scraped from catalogues. See `extract.Metadata.Merge`.
+func (n *NovelInfo) Complete(m *extract.Metadata) {
+	merged := &extract.Metadata{Name: n.Name, Author: n.Author, Cover: n.CoverURL, Synopsis: n.Synopsis, Genre: n.Genre, Tags: n.Tags, Status: n.Status, Source: n.Source}
+	merged.Merge(m)
+	n.Name, n.Author, n.CoverURL, n.Synopsis = merged.Name, merged.Author, merged.Cover, merged.Synopsis
+	n.Genre, n.Tags, n.Status, n.Source = merged.Genre, merged.Tags, merged.Status, merged.Source
+}
+
+// FetchCover download Cover from CoverURL, unless Cover exists or CoverURL is empty.
+func (n *NovelInfo) FetchCover() error {
+	if len(n.Cover) > 0 || n.CoverURL == "" {
+		return nil
+	}
+	bodies, errs, ioCompletes := utils.Fetch([]string{n.CoverURL}, &utils.FetchOption{Binary: true})
+	defer utils.WaitSync(ioCompletes)
+	if errs[0] != nil {
+		return errs[0]
+	}
+	n.Cover = []byte(*bodies[0])
+	return nil
+}
+
+// txtHeader give lines of header in .txt, e.g. `Name:\t...`. Empty optional fields are omitted.
+func txtHeader(n NovelInfo) (ret string) {
+	ret += "Name" + headerSeparator + n.Name + "\n"
+	ret += "Author" + headerSeparator + n.Author + "\n"
+	for _, f := range infoFields {
+		if v := f.get(&n); v != "" {
+			ret += f.label + headerSeparator + v + "\n"
+		}
+	}
+	return
+}
+
+// epubInfo give the section of optional fields in .epub, or empty if there is none.
+func epubInfo(n NovelInfo) string {
+	var b strings.Builder
+	for _, f := range infoFields {
+		if v := f.get(&n); v != "" {
+			b.WriteString(`<p class="` + strings.ToLower(f.label) + `">` + f.label + infoSeparator + html.EscapeString(v) + `</p>`)
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return `<h1>` + html.EscapeString(n.Name) + `</h1>` + `<div id="info">` + b.String() + `</div>`
+}
+
+// opfMetadata give elements of metadata in .opf for Genre, Tags and Status, or empty if there is none.
+// Genre and Tags are `<dc:subject>`, where Genre is told apart by `id="genre"`, and Status is `<meta name="status">`.
+func opfMetadata(n NovelInfo) (ret string) {
+	if n.Genre != "" {
+		ret += `<dc:subject id="` + genreID + `">` + html.EscapeString(n.Genre) + `</dc:subject>`
+	}
+	for _, tag := range n.Tags {
+		if tag != "" {
+			ret += `<dc:subject>` + html.EscapeString(tag) + `</dc:subject>`
+		}
+	}
+	if n.Status != "" {
+		ret += `<meta name="` + statusMeta + `" content="` + html.EscapeString(n.Status) + `"/>`
+	}
+	return
+}
+
+// AddOpfMetadata insert Genre, Tags and Status of novelInfo into metadata of .opf in the .epub at filePath.
+// NOTICE: go-epub only writes title, author and description, so the .epub is rewritten after `epub.Write`.
+// Other files are copied as they are, keeping `mimetype` first and uncompressed.
+func AddOpfMetadata(filePath string, novelInfo NovelInfo) (e error) {
+	metadata := opfMetadata(novelInfo)
+	if metadata == "" {
+		return nil
+	}
+	r, e := zip.OpenReader(filePath)
+	if e != nil {
+		return
+	}
+	defer r.Close()
+	f, e := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+"-*")
+	if e != nil {
+		return
+	}
+	defer func() {
+		f.Close()
+		if e != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	z := zip.NewWriter(f)
+	for _, file := range r.File {
+		if path.Ext(file.Name) != ".opf" {
+			if e = z.Copy(file); e != nil {
+				return
+			}
+			continue
+		}
+		var b []byte
+		if b, e = readZipFile(file); e != nil {
+			return
+		}
+		header := file.FileHeader
+		var w io.Writer
+		if w, e = z.CreateHeader(&header); e != nil {
+			return
+		}
+		if _, e = w.Write([]byte(strings.Replace(string(b), "</metadata>", metadata+"</metadata>", 1))); e != nil {
+			return
+		}
+	}
+	if e = z.Close(); e != nil {
+		return
+	}
+	if e = f.Close(); e != nil {
+		return
+	}
+	return os.Rename(f.Name(), filePath)
+}
+
+// writeImageFile write image to a temporary file named after name, since images are added to .epub from files.
+// Images of unknown types are perceived as `.jpg`. The caller should remove the file after writing .epub.
+// @return ext string extension of the image, e.g. `.jpg`.
+func writeImageFile(image []byte, name string) (filePath string, ext string, e error) {
+	ext, ok := imageExtensions[http.DetectContentType(image)]
+	if !ok {
+		ext = ".jpg"
+	}
+	f, e := ioutil.TempFile("", name+"-*"+ext)
+	if e != nil {
+		return
+	}
+	defer f.Close()
+	if _, e = f.Write(image); e != nil {
+		os.Remove(f.Name())
+		return
+	}
+	return f.Name(), ext, nil
+}
@@ -0,0 +1,73 @@
+package write_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/write"
+)
+
+func TestComplete(t *testing.T) {
+	info := write.NovelInfo{Name: "诡秘之主", Genre: "奇幻"}
+	info.Complete(&extract.Metadata{Name: "诡秘之主最新章节", Author: "爱潜水的乌贼", Cover: "https://example.com/cover.jpg", Synopsis: "蒸汽与机械", Genre: "玄幻", Tags: []string{"克苏鲁"}, Status: extract.StatusCompleted, Source: "https://example.com/book/1/"})
+	expect := write.NovelInfo{Name: "诡秘之主", Author: "爱潜水的乌贼", CoverURL: "https://example.com/cover.jpg", Synopsis: "蒸汽与机械", Genre: "奇幻", Tags: []string{"克苏鲁"}, Status: extract.StatusCompleted, Source: "https://example.com/book/1/"}
+	if !reflect.DeepEqual(info, expect) {
+		t.Errorf("Get %v. Expect %v.\n", info, expect)
+	}
+	info.Complete(nil)
+	if !reflect.DeepEqual(info, expect) {
+		t.Errorf("Get %v. Expect %v.\n", info, expect)
+	}
+}
+
+func TestAddOpfMetadata(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "novel.epub")
+	f, err := os.Create(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := zip.NewWriter(f)
+	w, _ := z.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	w.Write([]byte("application/epub+zip"))
+	w, _ = z.Create("EPUB/package.opf")
+	w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><package xmlns="http://www.idpf.org/2007/opf"><metadata xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>诡秘之主</dc:title><dc:creator>爱潜水的乌贼</dc:creator></metadata></package>`))
+	w, _ = z.Create("EPUB/xhtml/1.xhtml")
+	w.Write([]byte(`<html><body><h2>第一章 绯红</h2><div id="content"><p>    痛！</p></div></body></html>`))
+	z.Close()
+	f.Close()
+
+	info := write.NovelInfo{Name: "诡秘之主", Author: "爱潜水的乌贼", Genre: "玄幻", Tags: []string{"克苏鲁", "蒸汽&朋克"}, Status: extract.StatusCompleted}
+	if err := write.AddOpfMetadata(filePath, info); err != nil {
+		t.Fatalf("Get %v while adding metadata.\n", err)
+	}
+	r, err := zip.OpenReader(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if len(r.File) != 3 || r.File[0].Name != "mimetype" || r.File[0].Method != zip.Store {
+		t.Errorf("Get %v. Expect `mimetype` first and uncompressed.\n", r.File)
+	}
+	_, i, err := write.Read(filePath)
+	if err != nil {
+		t.Fatalf("Get %v while reading.\n", err)
+	}
+	if !reflect.DeepEqual(i, info) {
+		t.Errorf("Get %v. Expect %v.\n", i, info)
+	}
+	opf, _ := r.File[1].Open()
+	defer opf.Close()
+	b := new(bytes.Buffer)
+	b.ReadFrom(opf)
+	for _, expect := range []string{`<dc:subject id="genre">玄幻</dc:subject>`, `<dc:subject>蒸汽&amp;朋克</dc:subject>`, `<meta name="status" content="completed"/>`} {
+		if !strings.Contains(b.String(), expect) {
+			t.Errorf("Get %v. Expect %v in it.\n", b.String(), expect)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// json convert Chapters to .json or .jsonl file and back, so that other tools can process them, and other formats
+// can be rendered without fetching again.
+package write
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+// Book is the content of .json file.
+type Book struct {
+	Info     NovelInfo        `json:"info"`
+	Chapters extract.Chapters `json:"chapters"`
+}
+
+func newEncoder(b *bytes.Buffer) *json.Encoder {
+	encoder := json.NewEncoder(b)
+	encoder.SetEscapeHTML(false)
+	return encoder
+}
+
+// WriteToJSON write novelInfo and chapters to filePath as a single JSON object. See `Book`.
+// @param options *WriteOption (default: no conversion, no normalization) Provenance is always written.
+func WriteToJSON(writer io.Writer, chapters extract.Chapters, filePath string, novelInfo NovelInfo, options *WriteOption) (e error) {
+	if !strings.HasSuffix(filePath, ".json") {
+		filePath += ".json"
+	}
+	if options == nil {
+		options = &WriteOption{}
+	}
+	chapters, novelInfo = options.apply(chapters, novelInfo)
+	fmt.Printf("Writing to file %s...\n", filepath.Base(filePath))
+	var b bytes.Buffer
+	encoder := newEncoder(&b)
+	encoder.SetIndent("", "  ")
+	if e = encoder.Encode(Book{Info: novelInfo, Chapters: chapters}); e != nil {
+		return
+	}
+	fmt.Fprintf(writer, "%s", outputIOText)
+	data := b.String()
+	return utils.WriteFileString(filePath, &data, false)
+}
+
+// WriteToJSONL write novelInfo and chapters to filePath in JSON Lines.
+// The first line is novelInfo, and every following line is a chapter.
+// @param options *WriteOption (default: no conversion, no normalization) Provenance is always written.
+func WriteToJSONL(writer io.Writer, chapters extract.Chapters, filePath string, novelInfo NovelInfo, options *WriteOption) (e error) {
+	if !strings.HasSuffix(filePath, ".jsonl") {
+		filePath += ".jsonl"
+	}
+	if options == nil {
+		options = &WriteOption{}
+	}
+	chapters, novelInfo = options.apply(chapters, novelInfo)
+	fmt.Printf("Writing to file %s...\n", filepath.Base(filePath))
+	var b bytes.Buffer
+	encoder := newEncoder(&b)
+	if e = encoder.Encode(novelInfo); e != nil {
+		return
+	}
+	for _, c := range chapters {
+		if e = encoder.Encode(c); e != nil {
+			return
+		}
+	}
+	fmt.Fprintf(writer, "%s", outputIOText)
+	data := b.String()
+	return utils.WriteFileString(filePath, &data, false)
+}
+
+// ParseJSON parse content of file written by `WriteToJSON`.
+func ParseJSON(data string) (extract.Chapters, NovelInfo, error) {
+	var book Book
+	if err := json.Unmarshal([]byte(data), &book); err != nil {
+		return nil, NovelInfo{}, err
+	}
+	return book.Chapters, book.Info, nil
+}
+
+// ParseJSONL parse content of file written by `WriteToJSONL`. Empty lines are skipped.
+func ParseJSONL(data string) (chapters extract.Chapters, novelInfo NovelInfo, e error) {
+	r := bufio.NewScanner(strings.NewReader(data))
+	r.Buffer(nil, len(data)+1) // NOTICE: A line holds a whole chapter, which exceeds the default limit.
+	first := true
+	for r.Scan() {
+		line := strings.TrimSpace(r.Text())
+		if line == "" {
+			continue
+		}
+		if first {
+			if e = json.Unmarshal([]byte(line), &novelInfo); e != nil {
+				return
+			}
+			first = false
+			continue
+		}
+		c := &extract.Chapter{}
+		if e = json.Unmarshal([]byte(line), c); e != nil {
+			return
+		}
+		chapters = append(chapters, c)
+	}
+	if first {
+		return nil, novelInfo, utils.Shortage
+	}
+	return chapters, novelInfo, r.Err()
+}
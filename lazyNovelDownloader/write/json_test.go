@@ -0,0 +1,42 @@
+package write_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/write"
+)
+
+func TestJSON(t *testing.T) {
+	info := write.NovelInfo{Name: "诡秘之主", Author: "爱潜水的乌贼", Cover: []byte("\x89PNG\r\n\x1a\n\xff\xfe"), Tags: []string{"克苏鲁"}, Status: extract.StatusOngoing}
+	chapters := extract.Chapters{
+		&extract.Chapter{Name: "第一章 绯红", Url: "https://example.com/1.html", Content: "    痛！\n    好痛！\n", Fetch: true, Source: "example.com", FetchTime: time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC), Score: 0.9, Hash: extract.ContentHash("    痛！\n    好痛！\n")},
+		&extract.Chapter{Name: "第二章 情况", Url: "https://example.com/2.html"},
+	}
+	dir := t.TempDir()
+	for _, format := range []string{"json", "jsonl"} {
+		filePath := filepath.Join(dir, "novel")
+		if err := write.Write(ioutil.Discard, format, chapters, filePath, info, nil); err != nil {
+			t.Fatalf("Get %v while writing %v.\n", err, format)
+		}
+		c, i, err := write.Read(filePath + "." + format)
+		if err != nil {
+			t.Fatalf("Get %v while reading %v.\n", err, format)
+		}
+		if !reflect.DeepEqual(i, info) {
+			t.Errorf("Get %v from %v. Expect %v.\n", i, format, info)
+		}
+		if len(c) != len(chapters) {
+			t.Fatalf("Get %v chapters from %v. Expect %v.\n", len(c), format, len(chapters))
+		}
+		for j := range c {
+			if !reflect.DeepEqual(c[j], chapters[j]) {
+				t.Errorf("Get %v from %v. Expect %v.\n", *c[j], format, *chapters[j])
+			}
+		}
+	}
+}
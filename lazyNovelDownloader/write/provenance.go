@@ -0,0 +1,74 @@
+// provenance record where every chapter comes from, so that garbage can be traced back to its source.
+package write
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+const (
+	provenanceSuffix = ".provenance.json"
+)
+
+// Provenance is the record of a chapter in JSON sidecar.
+type Provenance struct {
+	Index     int       `json:"index"`
+	Name      string    `json:"name"`
+	Url       string    `json:"url"`
+	Source    string    `json:"source"`
+	FetchTime time.Time `json:"fetch_time"`
+	Score     float64   `json:"score"`
+	// Quality is the breakdown of Score if known.
+	Quality *extract.Quality `json:"quality,omitempty"`
+	Hash    string           `json:"hash"`
+	Problem string           `json:"problem,omitempty"`
+	// Catalogues are urls of catalogues having the chapter when merged.
+	Catalogues []string `json:"catalogues,omitempty"`
+}
+
+// Provenances give records of chapters, whose indexes are 1-based.
+func Provenances(chapters extract.Chapters) []Provenance {
+	rets := make([]Provenance, len(chapters), len(chapters))
+	for i, c := range chapters {
+		rets[i] = Provenance{Index: i + 1, Name: c.Name, Url: c.Url, Source: c.Source, FetchTime: c.FetchTime, Score: c.Score, Quality: c.Quality, Hash: c.Hash, Problem: c.Problem, Catalogues: c.Catalogues}
+	}
+	return rets
+}
+
+// WriteProvenance write records of chapters to filePath in JSON.
+func WriteProvenance(chapters extract.Chapters, filePath string) error {
+	data, err := json.MarshalIndent(Provenances(chapters), "", "  ")
+	if err != nil {
+		return err
+	}
+	s := string(data)
+	return utils.WriteFileString(filePath, &s, false)
+}
+
+// EpubProvenance give the hidden footer of chapter in EPUB, which records the same as `Provenance`.
+// Quality is in JSON, and urls of catalogues are separated by spaces. See `ParseEpubChapter`.
+func EpubProvenance(c *extract.Chapter) string {
+	if !c.Fetch {
+		return ""
+	}
+	extra := ""
+	if c.Quality != nil {
+		if data, err := json.Marshal(c.Quality); err == nil {
+			extra += ` data-quality="` + html.EscapeString(string(data)) + `"`
+		}
+	}
+	if len(c.Catalogues) > 0 {
+		extra += ` data-catalogues="` + html.EscapeString(strings.Join(c.Catalogues, " ")) + `"`
+	}
+	if c.Problem != "" {
+		extra += ` data-problem="` + html.EscapeString(c.Problem) + `"`
+	}
+	return fmt.Sprintf(`<div class="provenance" hidden="hidden" data-source="%s" data-fetch-time="%s" data-score="%.3f" data-hash="%s"%s>%s</div>`,
+		html.EscapeString(c.Source), c.FetchTime.Format(time.RFC3339), c.Score, c.Hash, extra, html.EscapeString(c.Url))
+}
@@ -0,0 +1,41 @@
+package write_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/write"
+)
+
+func TestEpubProvenance(t *testing.T) {
+	c := &extract.Chapter{
+		Name: "第一章 绯红", Url: "https://example.com/1.html?a=1&b=2", Content: "    痛！\n", Fetch: true,
+		Source: "example.com", FetchTime: time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC), Score: 0.9, Hash: "abc",
+		Quality:    &extract.Quality{CJK: 0.9, Ads: 1, Paragraphs: 0.4, Punctuation: 1, Garbled: 1},
+		Catalogues: []string{"https://example.com/book/1/", "https://example.org/0_1/"},
+		Problem:    "short",
+	}
+	foot := write.EpubProvenance(c)
+	for _, s := range []string{
+		`data-source="example.com"`, `data-fetch-time="2020-05-01T08:00:00Z"`, `data-score="0.900"`, `data-hash="abc"`,
+		`data-quality="{&#34;CJK&#34;:0.9,`, `data-catalogues="https://example.com/book/1/ https://example.org/0_1/"`, `data-problem="short"`,
+		`>https://example.com/1.html?a=1&amp;b=2</div>`,
+	} {
+		if !strings.Contains(foot, s) {
+			t.Errorf("Get %v. Expect including %v.\n", foot, s)
+		}
+	}
+	ret, err := write.ParseEpubChapter([]byte(`<html><body><h2>` + c.Name + `</h2><div id="content"><p>    痛！</p></div><div id="foot">` + foot + `</div></body></html>`))
+	if err != nil {
+		t.Fatalf("Get %v while parsing.\n", err)
+	}
+	if !reflect.DeepEqual(ret, c) {
+		t.Errorf("Get %v. Expect %v.\n", *ret, *c)
+	}
+	if foot := write.EpubProvenance(&extract.Chapter{Name: "第二章 情况"}); foot != "" {
+		t.Errorf("Get %v from chapter not fetched. Expect empty.\n", foot)
+	}
+}
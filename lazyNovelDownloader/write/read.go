@@ -0,0 +1,337 @@
+// read parse .txt and .epub files written by `WriteToTxt` and `WriteToEpub` back into Chapters,
+// so that they can be written in other formats without fetching again.
+package write
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/utils"
+	"golang.org/x/net/html"
+)
+
+const (
+	headerSeparator = ":\t"
+)
+
+// isParagraph tells whether line of .txt belongs to content, which is indented.
+func isParagraph(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "　")
+}
+
+// ParseTxt parse content of file written by `WriteToTxt`.
+// Lines before the header `Name:`, i.e. the prologue, are skipped. Files without header are parsed from the beginning.
+// Every line not indented is the name of a chapter, followed by indented paragraphs or `Lack`.
+func ParseTxt(data string) (chapters extract.Chapters, novelInfo NovelInfo, e error) {
+	if i := strings.Index(data, "\nName"+headerSeparator); i != -1 {
+		data = data[i+1:]
+	} else if strings.HasPrefix(data, Prologue) {
+		data = data[len(Prologue):]
+	}
+	r := bufio.NewScanner(strings.NewReader(data))
+	r.Buffer(nil, len(data)+1)
+	inHeader := true
+	var c *extract.Chapter
+	for r.Scan() {
+		line := r.Text()
+		if inHeader {
+			if pos := strings.Index(line, headerSeparator); pos != -1 && !isParagraph(line) {
+				switch key, value := line[:pos], line[pos+len(headerSeparator):]; key {
+				case "Name":
+					novelInfo.Name = value
+				case "Author":
+					novelInfo.Author = value
+				default:
+					if f, ok := lookupInfoField(key); ok {
+						f.set(&novelInfo, value)
+					}
+				}
+				continue
+			}
+			inHeader = false
+		}
+		switch {
+		case strings.TrimSpace(line) == "":
+		case isParagraph(line):
+			if c != nil {
+				c.Content += line + "\n"
+				c.Fetch = true
+			}
+		case line == Lack && c != nil && c.Content == "":
+			c.Fetch = false
+		default:
+			c = &extract.Chapter{Name: line}
+			chapters = append(chapters, c)
+		}
+	}
+	return chapters, novelInfo, r.Err()
+}
+
+// ReadFromTxt read file written by `WriteToTxt`, together with its provenance if exists.
+func ReadFromTxt(filePath string) (extract.Chapters, NovelInfo, error) {
+	data, err := utils.ReadFileString(filePath)
+	if err != nil {
+		return nil, NovelInfo{}, err
+	}
+	chapters, novelInfo, err := ParseTxt(data)
+	if err != nil {
+		return nil, novelInfo, err
+	}
+	// NOTICE: Provenance is optional.
+	if data, err := utils.ReadFileString(strings.TrimSuffix(filePath, ".txt") + provenanceSuffix); err == nil {
+		var records []Provenance
+		if json.Unmarshal([]byte(data), &records) == nil {
+			restoreProvenance(chapters, records)
+		}
+	}
+	return chapters, novelInfo, nil
+}
+
+// restoreProvenance fill chapters with records of the same indexes and names.
+func restoreProvenance(chapters extract.Chapters, records []Provenance) {
+	for _, p := range records {
+		if p.Index < 1 || p.Index > len(chapters) || chapters[p.Index-1].Name != p.Name {
+			continue
+		}
+		c := chapters[p.Index-1]
+		c.Url, c.Source, c.FetchTime, c.Score, c.Quality, c.Hash, c.Problem, c.Catalogues = p.Url, p.Source, p.FetchTime, p.Score, p.Quality, p.Hash, p.Problem, p.Catalogues
+	}
+}
+
+// chapterFile match file of chapter in .epub, e.g. `EPUB/xhtml/12.xhtml`.
+var chapterFile = regexp.MustCompile(`(?:^|/)(\d+)\.xhtml$`)
+
+type opfPackage struct {
+	Title       string `xml:"metadata>title"`
+	Creator     string `xml:"metadata>creator"`
+	Description string `xml:"metadata>description"`
+	Subjects    []struct {
+		ID    string `xml:"id,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"metadata>subject"`
+	Metas []struct {
+		Name    string `xml:"name,attr"`
+		Content string `xml:"content,attr"`
+	} `xml:"metadata>meta"`
+	Items []struct {
+		ID         string `xml:"id,attr"`
+		Href       string `xml:"href,attr"`
+		Properties string `xml:"properties,attr"`
+	} `xml:"manifest>item"`
+}
+
+// info fill Genre, Tags and Status of novelInfo by metadata written by `AddOpfMetadata`.
+func (opf *opfPackage) info(novelInfo *NovelInfo) {
+	for _, s := range opf.Subjects {
+		if s.ID == genreID {
+			novelInfo.Genre = s.Value
+		} else {
+			novelInfo.Tags = append(novelInfo.Tags, s.Value)
+		}
+	}
+	for _, m := range opf.Metas {
+		if m.Name == statusMeta {
+			novelInfo.Status = m.Content
+		}
+	}
+}
+
+// cover give href of cover image, by `cover-image` of manifest or `<meta name="cover">`.
+func (opf *opfPackage) cover() string {
+	id := ""
+	for _, m := range opf.Metas {
+		if m.Name == "cover" {
+			id = m.Content
+		}
+	}
+	for _, item := range opf.Items {
+		if strings.Contains(item.Properties, "cover-image") || (id != "" && item.ID == id) {
+			return item.Href
+		}
+	}
+	return ""
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// ParseEpubChapter parse a section of chapter written by `WriteToEpub`.
+func ParseEpubChapter(data []byte) (*extract.Chapter, error) {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	c := &extract.Chapter{}
+	// NOTICE: Images embedded are read back as placeholders. See `epubImages`.
+	for _, img := range utils.Select(doc, "#content img") {
+		if url := attrValue(img, "data-source"); url != "" && img.Parent != nil {
+			img.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: extract.ImageText(url)}, img)
+		}
+	}
+	if nodes := utils.Select(doc, "h2"); len(nodes) > 0 {
+		c.Name = strings.TrimSpace(utils.ExtractText(nodes[0], "", nil))
+	}
+	for _, p := range utils.Select(doc, "#content p") {
+		c.Content += utils.ExtractText(p, "", nil) + "\n"
+	}
+	if c.Fetch = c.Content != "" && c.Content != Lack+"\n"; !c.Fetch {
+		c.Content = ""
+	}
+	if nodes := utils.Select(doc, ".provenance"); len(nodes) > 0 {
+		n := nodes[0]
+		for _, attr := range n.Attr {
+			switch attr.Key {
+			case "data-source":
+				c.Source = attr.Val
+			case "data-fetch-time":
+				c.FetchTime, _ = time.Parse(time.RFC3339, attr.Val)
+			case "data-score":
+				c.Score, _ = strconv.ParseFloat(attr.Val, 64)
+			case "data-hash":
+				c.Hash = attr.Val
+			case "data-quality":
+				var q extract.Quality
+				if json.Unmarshal([]byte(attr.Val), &q) == nil {
+					c.Quality = &q
+				}
+			case "data-catalogues":
+				c.Catalogues = strings.Fields(attr.Val)
+			case "data-problem":
+				c.Problem = attr.Val
+			}
+		}
+		c.Url = strings.TrimSpace(utils.ExtractText(n, "", nil))
+	}
+	return c, nil
+}
+
+// parseEpubInfo parse the section of information written by `WriteToEpub` into novelInfo.
+func parseEpubInfo(data []byte, novelInfo *NovelInfo) error {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for _, p := range utils.Select(doc, "#info p") {
+		f, ok := lookupInfoField(attrValue(p, "class"))
+		if !ok {
+			continue
+		}
+		text := utils.ExtractText(p, "", nil)
+		if pos := strings.Index(text, infoSeparator); pos != -1 {
+			f.set(novelInfo, text[pos+len(infoSeparator):])
+		}
+	}
+	return nil
+}
+
+func attrValue(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// ReadFromEpub read file written by `WriteToEpub`. Chapters are sections named by their indexes.
+// Information of novel is read from metadata, the section of information and the cover image.
+func ReadFromEpub(filePath string) (chapters extract.Chapters, novelInfo NovelInfo, e error) {
+	data, e := utils.ReadFileBytes(filePath)
+	if e != nil {
+		return
+	}
+	z, e := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if e != nil {
+		return
+	}
+	type section struct {
+		index int
+		file  *zip.File
+	}
+	var sections []section
+	var info *zip.File
+	var coverPath string
+	files := make(map[string]*zip.File)
+	for _, f := range z.File {
+		files[f.Name] = f
+		if m := chapterFile.FindStringSubmatch(f.Name); m != nil {
+			index, _ := strconv.Atoi(m[1])
+			sections = append(sections, section{index, f})
+		} else if path.Ext(f.Name) == ".opf" {
+			b, err := readZipFile(f)
+			if err != nil {
+				return nil, novelInfo, err
+			}
+			var opf opfPackage
+			if err = xml.Unmarshal(b, &opf); err != nil {
+				return nil, novelInfo, err
+			}
+			novelInfo.Name, novelInfo.Author = opf.Title, opf.Creator
+			if opf.Description != Prologue {
+				novelInfo.Synopsis = opf.Description
+			}
+			opf.info(&novelInfo)
+			if href := opf.cover(); href != "" {
+				coverPath = path.Join(path.Dir(f.Name), href)
+			}
+		} else if path.Base(f.Name) == infoFile {
+			info = f
+		}
+	}
+	// NOTICE: Without manifest, the cover is the image named `cover` under `images`, instead of `cover.xhtml` or `cover.css`.
+	if coverPath == "" {
+		for name := range files {
+			if base := path.Base(name); path.Base(path.Dir(name)) == "images" && strings.TrimSuffix(base, path.Ext(base)) == coverName {
+				coverPath = name
+			}
+		}
+	}
+	if f, ok := files[coverPath]; ok {
+		if novelInfo.Cover, e = readZipFile(f); e != nil {
+			return nil, novelInfo, e
+		}
+	}
+	// NOTICE: The section of information is parsed after metadata, and overrides fields in both.
+	if info != nil {
+		b, err := readZipFile(info)
+		if err != nil {
+			return nil, novelInfo, err
+		}
+		if err = parseEpubInfo(b, &novelInfo); err != nil {
+			return nil, novelInfo, err
+		}
+	}
+	sort.Slice(sections, func(i, j int) bool { return sections[i].index < sections[j].index })
+	for _, s := range sections {
+		b, err := readZipFile(s.file)
+		if err != nil {
+			return nil, novelInfo, err
+		}
+		c, err := ParseEpubChapter(b)
+		if err != nil {
+			return nil, novelInfo, err
+		}
+		chapters = append(chapters, c)
+	}
+	if len(sections) == 0 {
+		return nil, novelInfo, utils.Shortage
+	}
+	return
+}
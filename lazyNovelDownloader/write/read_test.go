@@ -0,0 +1,87 @@
+package write_test
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/write"
+)
+
+func TestReadFromTxt(t *testing.T) {
+	info := write.NovelInfo{Name: "诡秘之主", Author: "爱潜水的乌贼", CoverURL: "https://example.com/cover.jpg", Synopsis: "蒸汽与机械的浪潮中，谁能触及非凡？", Genre: "玄幻", Tags: []string{"克苏鲁", "蒸汽朋克"}, Status: extract.StatusCompleted, Source: "https://example.com/book/1/"}
+	chapters := extract.Chapters{
+		&extract.Chapter{Name: "第一章 绯红", Url: "https://example.com/1.html", Content: "    痛！\n    好痛！\n", Fetch: true, Source: "example.com", Score: 0.9, Quality: &extract.Quality{CJK: 0.9, Ads: 1, Paragraphs: 0.4, Punctuation: 1, Garbled: 1}, Catalogues: []string{"https://example.com/", "https://example.org/"}},
+		&extract.Chapter{Name: "第二章 情况", Url: "https://example.com/2.html"},
+		&extract.Chapter{Name: "第三章 笔记", Url: "https://example.com/3.html", Content: "    克莱恩翻开了笔记。\n", Fetch: true},
+	}
+	filePath := filepath.Join(t.TempDir(), "novel")
+	if err := write.WriteToTxt(ioutil.Discard, chapters, filePath, info, &write.WriteOption{Provenance: true}); err != nil {
+		t.Fatalf("Get %v while writing.\n", err)
+	}
+	c, i, err := write.Read(filePath + ".txt")
+	if err != nil {
+		t.Fatalf("Get %v while reading.\n", err)
+	}
+	if !reflect.DeepEqual(i, info) {
+		t.Errorf("Get %v. Expect %v.\n", i, info)
+	}
+	if len(c) != len(chapters) {
+		t.Fatalf("Get %v chapters. Expect %v.\n", len(c), len(chapters))
+	}
+	for j := range c {
+		if !reflect.DeepEqual(c[j], chapters[j]) {
+			t.Errorf("Get %v. Expect %v.\n", *c[j], *chapters[j])
+		}
+	}
+}
+
+func TestReadFromEpub(t *testing.T) {
+	files := map[string]string{
+		"EPUB/package.opf":         `<?xml version="1.0" encoding="UTF-8"?><package xmlns="http://www.idpf.org/2007/opf"><metadata xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>诡秘之主</dc:title><dc:creator>爱潜水的乌贼</dc:creator><dc:description>蒸汽与机械的浪潮中</dc:description></metadata></package>`,
+		"EPUB/xhtml/catalog.xhtml": `<html><body><h1>Catalog</h1></body></html>`,
+		"EPUB/xhtml/info.xhtml":    `<html><body><h1>诡秘之主</h1><div id="info"><p class="genre">Genre: 玄幻</p><p class="tags">Tags: 克苏鲁, 蒸汽朋克</p><p class="status">Status: completed</p></div></body></html>`,
+		"EPUB/images/cover.png":    "\x89PNG",
+		"EPUB/css/cover.css":       `body { background-color: #FFFFFF; }`,
+		"EPUB/xhtml/cover.xhtml":   `<html><body><img src="../images/cover.png" alt="Cover Image" /></body></html>`,
+		"EPUB/xhtml/10.xhtml":      `<html><body><h2>第十一章 占卜</h2><div id="content"><p>` + write.Lack + `</p></div></body></html>`,
+		"EPUB/xhtml/1.xhtml":       `<html><body><h2>第二章 情况</h2><div id="content"><p>    痛！</p><p>    <img src="../images/image-1.png" alt="" data-source="https://example.com/1.png" /></p><p>    好痛！<a href="https://example.com/2.png">[Image: https://example.com/2.png]</a></p></div><div id="foot"><a href="catalog.xhtml">Back to Catalog</a><div class="provenance" hidden="hidden" data-source="example.com" data-score="0.900">https://example.com/2.html</div></div></body></html>`,
+	}
+	filePath := filepath.Join(t.TempDir(), "novel.epub")
+	f, err := os.Create(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := zip.NewWriter(f)
+	for name, content := range files {
+		w, _ := z.Create(name)
+		w.Write([]byte(content))
+	}
+	z.Close()
+	f.Close()
+
+	c, i, err := write.Read(filePath)
+	if err != nil {
+		t.Fatalf("Get %v while reading.\n", err)
+	}
+	info := write.NovelInfo{Name: "诡秘之主", Author: "爱潜水的乌贼", Cover: []byte("\x89PNG"), Synopsis: "蒸汽与机械的浪潮中", Genre: "玄幻", Tags: []string{"克苏鲁", "蒸汽朋克"}, Status: extract.StatusCompleted}
+	if !reflect.DeepEqual(i, info) {
+		t.Errorf("Get %v. Expect %v.\n", i, info)
+	}
+	expects := extract.Chapters{
+		&extract.Chapter{Name: "第二章 情况", Url: "https://example.com/2.html", Content: "    痛！\n    [Image: https://example.com/1.png]\n    好痛！[Image: https://example.com/2.png]\n", Fetch: true, Source: "example.com", Score: 0.9},
+		&extract.Chapter{Name: "第十一章 占卜"},
+	}
+	if len(c) != len(expects) {
+		t.Fatalf("Get %v chapters. Expect %v.\n", len(c), len(expects))
+	}
+	for j := range c {
+		if !reflect.DeepEqual(c[j], expects[j]) {
+			t.Errorf("Get %v. Expect %v.\n", *c[j], *expects[j])
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -13,7 +14,9 @@ import (
 
 	"github.com/bmaupin/go-epub"
 
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/chinese"
 	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/typography"
 )
 
 const (
@@ -33,20 +36,75 @@ const (
 )
 
 type NovelInfo struct {
-	Name   string
-	Author string
+	Name   string `json:"name"`
+	Author string `json:"author"`
+	// CoverURL is where Cover is downloaded from. See `FetchCover`.
+	CoverURL string   `json:"cover_url,omitempty"`
+	Cover    []byte   `json:"cover,omitempty"`
+	Synopsis string   `json:"synopsis,omitempty"`
+	Genre    string   `json:"genre,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Status   string   `json:"status,omitempty"` // extract.StatusOngoing, extract.StatusCompleted or as it is on the page
+	Source   string   `json:"source,omitempty"` // URL of catalogue
 }
 
-func WriteToTxt(writer io.Writer, chapters extract.Chapters, filePath string, novelInfo NovelInfo) (e error) {
+type WriteOption struct {
+	// Provenance tells where every chapter comes from, in a hidden footer of each chapter of .epub,
+	// or a JSON sidecar of .txt. See `WriteProvenance`.
+	Provenance bool
+	// Chinese convert names, contents and information of novel before writing, e.g. `chinese.Traditional()`.
+	// nil keeps them unchanged.
+	Chinese *chinese.Converter
+	// Typography normalize names and contents after converting, e.g. `typography.NewNormalizer()`. nil keeps them unchanged.
+	Typography *typography.Normalizer
+}
+
+// apply return chapters and novelInfo converted and normalized by options, leaving the originals unchanged.
+func (options *WriteOption) apply(chapters extract.Chapters, novelInfo NovelInfo) (extract.Chapters, NovelInfo) {
+	if options.Chinese == nil && options.Typography == nil {
+		return chapters, novelInfo
+	}
+	rets := make(extract.Chapters, len(chapters), len(chapters))
+	for i, c := range chapters {
+		converted := *c
+		converted.Name = options.Typography.Line(options.Chinese.Convert(c.Name))
+		if c.Fetch {
+			// NOTICE: Urls of images are kept, or they become dead links.
+			converted.Content = extract.MaskImages(c.Content, func(content string) string {
+				return options.Typography.Content(options.Chinese.Convert(content))
+			})
+		}
+		rets[i] = &converted
+	}
+	novelInfo.Name = options.Chinese.Convert(novelInfo.Name)
+	novelInfo.Author = options.Chinese.Convert(novelInfo.Author)
+	novelInfo.Synopsis = options.Chinese.Convert(novelInfo.Synopsis)
+	novelInfo.Genre = options.Chinese.Convert(novelInfo.Genre)
+	if novelInfo.Tags != nil {
+		tags := make([]string, len(novelInfo.Tags), len(novelInfo.Tags))
+		for i, tag := range novelInfo.Tags {
+			tags[i] = options.Chinese.Convert(tag)
+		}
+		novelInfo.Tags = tags
+	}
+	return rets, novelInfo
+}
+
+// WriteToTxt write chapters to filePath in plain text.
+// @param options *WriteOption (default: no provenance, no conversion, no normalization)
+func WriteToTxt(writer io.Writer, chapters extract.Chapters, filePath string, novelInfo NovelInfo, options *WriteOption) (e error) {
 	var display utils.Display
+	if options == nil {
+		options = &WriteOption{}
+	}
+	chapters, novelInfo = options.apply(chapters, novelInfo)
 	signal := make(chan struct{})
 	if !strings.HasSuffix(filePath, ".txt") {
 		filePath += ".txt"
 	}
 	fmt.Printf("Writing to file %s...\n", filepath.Base(filePath))
 	novel := Prologue + "\n"
-	novel += "Name:	" + novelInfo.Name + "\n"
-	novel += "Author:	" + novelInfo.Author + "\n"
+	novel += txtHeader(novelInfo)
 
 	// NOTICE: Omit Error Here
 	finish, _ := display.ProgressBar(&utils.ProgressBarOption{Writer: writer, Phase: []int{1}, Signal: [][]<-chan struct{}{[]<-chan struct{}{signal}}, Maximum: [][]int{[]int{len(chapters)}}, Prefix: [][]string{[]string{outputPrePostfixText + "Write: "}}, Postfix: [][]string{[]string{outputPrePostfixText}}})
@@ -64,14 +122,25 @@ func WriteToTxt(writer io.Writer, chapters extract.Chapters, filePath string, no
 	close(signal)
 	<-finish
 	fmt.Fprintf(writer, "%s", outputIOText)
-	e = utils.WriteFileString(filePath, &novel, false)
+	if e = utils.WriteFileString(filePath, &novel, false); e != nil {
+		return
+	}
+	if options.Provenance {
+		e = WriteProvenance(chapters, strings.TrimSuffix(filePath, ".txt")+provenanceSuffix)
+	}
 	return
 }
 
-func WriteToEpub(writer io.Writer, chapters extract.Chapters, filePath string, novelInfo NovelInfo) (e error) {
+// WriteToEpub write chapters to filePath in EPUB.
+// @param options *WriteOption (default: no provenance, no conversion, no normalization)
+func WriteToEpub(writer io.Writer, chapters extract.Chapters, filePath string, novelInfo NovelInfo, options *WriteOption) (e error) {
 	var display utils.Display
+	if options == nil {
+		options = &WriteOption{}
+	}
+	chapters, novelInfo = options.apply(chapters, novelInfo)
 	signal := make(chan struct{})
-	if !strings.HasPrefix(filePath, ".epub") {
+	if !strings.HasSuffix(filePath, ".epub") {
 		filePath += ".epub"
 	}
 	fmt.Printf("Writing to file %s...\n", filepath.Base(filePath))
@@ -79,13 +148,37 @@ func WriteToEpub(writer io.Writer, chapters extract.Chapters, filePath string, n
 	epub := epub.NewEpub(novelName)
 	// Set Novel Information
 	epub.SetAuthor(novelInfo.Author)
-	epub.SetDescription(Prologue)
+	if novelInfo.Synopsis != "" {
+		epub.SetDescription(novelInfo.Synopsis)
+	} else {
+		epub.SetDescription(Prologue)
+	}
+	if len(novelInfo.Cover) > 0 {
+		coverPath, ext, err := writeImageFile(novelInfo.Cover, coverName)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(coverPath) // NOTICE: The image is read when writing .epub.
+		imagePath, err := epub.AddImage(coverPath, coverName+ext)
+		if err != nil {
+			return err
+		}
+		epub.SetCover(imagePath, "")
+	}
+	images, imageFiles, e := addImages(epub, chapters)
+	defer removeFiles(imageFiles) // NOTICE: Images are read when writing .epub, as the cover.
+	if e != nil {
+		return
+	}
 	// Set CSS and Font
 	cssPath, _ := filepath.Abs(cssFile)
 	fontPath, _ := filepath.Abs(fontFile)
 	epub.AddFont(fontPath, fontFile)
 	epub.AddCSS(cssPath, cssFile)
 	epub.AddSection(EpubFormatString(Prologue), "Prologue", "prologue.xhtml", "")
+	if info := epubInfo(novelInfo); info != "" {
+		epub.AddSection(info, "Information", infoFile, "")
+	}
 	// Generate Catalog
 	catalog := `<h1>` + `Catalog` + `</h1>`
 	catalog += `<div id="catalog">`
@@ -103,7 +196,11 @@ func WriteToEpub(writer io.Writer, chapters extract.Chapters, filePath string, n
 		if !c.Fetch {
 			content = Lack
 		}
-		_, e = epub.AddSection(`<h2>`+c.Name+`</h2>`+`<div id="content">`+EpubFormatString(content)+`</div>`+`<div id="foot">`+`<a href="catalog.xhtml" align="right">Back to Catalog</a>`+`</div>`, c.Name, strconv.Itoa(finish)+".xhtml", "")
+		foot := `<a href="catalog.xhtml" align="right">Back to Catalog</a>`
+		if options.Provenance {
+			foot += EpubProvenance(c)
+		}
+		_, e = epub.AddSection(`<h2>`+c.Name+`</h2>`+`<div id="content">`+EpubFormatString(epubImages(content, images))+`</div>`+`<div id="foot">`+foot+`</div>`, c.Name, strconv.Itoa(finish)+".xhtml", "")
 		if e != nil {
 			close(signal)
 			<-Finish
@@ -114,7 +211,10 @@ func WriteToEpub(writer io.Writer, chapters extract.Chapters, filePath string, n
 	close(signal)
 	<-Finish
 	fmt.Fprintf(writer, "%s", outputIOText)
-	e = epub.Write(filePath)
+	if e = epub.Write(filePath); e != nil {
+		return
+	}
+	e = AddOpfMetadata(filePath, novelInfo)
 	return
 }
 
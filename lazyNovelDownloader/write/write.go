@@ -0,0 +1,61 @@
+// write dispatch chapters to writers and readers of every format.
+package write
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/RaymondJiangkw/Lazy/lazyNovelDownloader/extract"
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+type writeFunc func(writer io.Writer, chapters extract.Chapters, filePath string, novelInfo NovelInfo, options *WriteOption) error
+
+type readFunc func(filePath string) (extract.Chapters, NovelInfo, error)
+
+var writers = map[string]writeFunc{
+	"txt":   WriteToTxt,
+	"epub":  WriteToEpub,
+	"json":  WriteToJSON,
+	"jsonl": WriteToJSONL,
+}
+
+var readers = map[string]readFunc{
+	"txt":   ReadFromTxt,
+	"epub":  ReadFromEpub,
+	"json":  readWith(ParseJSON),
+	"jsonl": readWith(ParseJSONL),
+}
+
+// readWith read the whole file, and then parse it.
+func readWith(parse func(data string) (extract.Chapters, NovelInfo, error)) readFunc {
+	return func(filePath string) (extract.Chapters, NovelInfo, error) {
+		data, err := utils.ReadFileString(filePath)
+		if err != nil {
+			return nil, NovelInfo{}, err
+		}
+		return parse(data)
+	}
+}
+
+// Formats are what `Write` supports.
+var Formats = []string{"txt", "epub", "json", "jsonl"}
+
+// Write write chapters to filePath in format, which is one of `Formats`.
+func Write(writer io.Writer, format string, chapters extract.Chapters, filePath string, novelInfo NovelInfo, options *WriteOption) error {
+	w, ok := writers[format]
+	if !ok {
+		return utils.Invalid
+	}
+	return w(writer, chapters, filePath, novelInfo, options)
+}
+
+// Read read chapters and novelInfo from file written by `Write`, whose format is told by extension.
+func Read(filePath string) (extract.Chapters, NovelInfo, error) {
+	read, ok := readers[strings.ToLower(strings.TrimPrefix(filepath.Ext(filePath), "."))]
+	if !ok {
+		return nil, NovelInfo{}, utils.Invalid
+	}
+	return read(filePath)
+}
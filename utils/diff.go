@@ -0,0 +1,214 @@
+package utils
+
+import "fmt"
+
+// EditKind is the kind of edit in `EditScript`.
+type EditKind uint
+
+const (
+	EditInsert EditKind = 1 // Elements of dst are inserted.
+	EditDelete EditKind = 2 // Elements of src are deleted.
+	EditEqual  EditKind = 3 // Elements of src are kept, which equal those of dst.
+)
+
+// Deprecated: Use EditInsert, EditDelete and EditEqual instead.
+const (
+	INSERT = EditInsert
+	DELETE = EditDelete
+	MOVE   = EditEqual
+)
+
+func (k EditKind) String() string {
+	switch k {
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	case EditEqual:
+		return "equal"
+	}
+	return fmt.Sprintf("EditKind(%d)", uint(k))
+}
+
+// Hunk is a run of edits of the same kind, covering src[SrcStart:SrcEnd] and dst[DstStart:DstEnd].
+// Insertions cover no element of src, and deletions no element of dst.
+type Hunk struct {
+	Kind             EditKind
+	SrcStart, SrcEnd int
+	DstStart, DstEnd int
+}
+
+// EditScript turn src into dst hunk by hunk. Between equal hunks, deletions always come before insertions.
+type EditScript []Hunk
+
+// Distance give the number of inserted and deleted elements.
+func (s EditScript) Distance() (ret int) {
+	for _, h := range s {
+		switch h.Kind {
+		case EditInsert:
+			ret += h.DstEnd - h.DstStart
+		case EditDelete:
+			ret += h.SrcEnd - h.SrcStart
+		}
+	}
+	return
+}
+
+// Diff give the shortest edit script turning src into dst.
+func Diff[T comparable](src, dst []T) EditScript {
+	return DiffFunc(src, dst, func(a, b T) bool { return a == b })
+}
+
+// DiffFunc works the same as `Diff`, but compares elements by equal, e.g. normalized titles of chapters.
+// It is the linear-space variant of Myers Algorithm, which divides the problem at the middle snake
+// as Hirschberg does, taking O((N+M)·D) time and O(N+M) space.
+func DiffFunc[T any](src, dst []T, equal func(a, b T) bool) EditScript {
+	size := (len(src)+len(dst)+1)/2 + 1
+	d := &differ[T]{src: src, dst: dst, equal: equal, forward: make([]int, 2*size+2), backward: make([]int, 2*size+2)}
+	d.compare(0, len(src), 0, len(dst))
+	return d.normalize()
+}
+
+type differ[T any] struct {
+	src, dst          []T
+	equal             func(a, b T) bool
+	forward, backward []int // V of both directions, reused among divisions
+	script            EditScript
+}
+
+// add append a hunk, merging it into the last one if possible.
+func (d *differ[T]) add(kind EditKind, srcStart, srcEnd, dstStart, dstEnd int) {
+	if srcStart == srcEnd && dstStart == dstEnd {
+		return
+	}
+	if n := len(d.script); n > 0 {
+		if last := &d.script[n-1]; last.Kind == kind && last.SrcEnd == srcStart && last.DstEnd == dstStart {
+			last.SrcEnd, last.DstEnd = srcEnd, dstEnd
+			return
+		}
+	}
+	d.script = append(d.script, Hunk{Kind: kind, SrcStart: srcStart, SrcEnd: srcEnd, DstStart: dstStart, DstEnd: dstEnd})
+}
+
+// compare diff src[srcStart:srcEnd] and dst[dstStart:dstEnd].
+func (d *differ[T]) compare(srcStart, srcEnd, dstStart, dstEnd int) {
+	// Common prefix and suffix
+	prefix := 0
+	for srcStart+prefix < srcEnd && dstStart+prefix < dstEnd && d.equal(d.src[srcStart+prefix], d.dst[dstStart+prefix]) {
+		prefix++
+	}
+	d.add(EditEqual, srcStart, srcStart+prefix, dstStart, dstStart+prefix)
+	srcStart, dstStart = srcStart+prefix, dstStart+prefix
+	suffix := 0
+	for srcStart < srcEnd-suffix && dstStart < dstEnd-suffix && d.equal(d.src[srcEnd-suffix-1], d.dst[dstEnd-suffix-1]) {
+		suffix++
+	}
+	srcEnd, dstEnd = srcEnd-suffix, dstEnd-suffix
+	defer d.add(EditEqual, srcEnd, srcEnd+suffix, dstEnd, dstEnd+suffix)
+
+	switch {
+	case srcStart == srcEnd:
+		d.add(EditInsert, srcStart, srcStart, dstStart, dstEnd)
+	case dstStart == dstEnd:
+		d.add(EditDelete, srcStart, srcEnd, dstStart, dstStart)
+	default:
+		x, y := d.middleSnake(srcStart, srcEnd, dstStart, dstEnd)
+		d.compare(srcStart, x, dstStart, y)
+		d.compare(x, srcEnd, y, dstEnd)
+	}
+}
+
+// middleSnake search forward and backward at the same time, and return where the paths overlap,
+// which divides the shortest edit script into halves.
+func (d *differ[T]) middleSnake(srcStart, srcEnd, dstStart, dstEnd int) (int, int) {
+	n, m := srcEnd-srcStart, dstEnd-dstStart
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	forward, backward := d.forward[:2*maxD+3], d.backward[:2*maxD+3]
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+	delta := n - m
+	odd := delta%2 != 0
+	// Diagonals out of the rectangle are skipped.
+	kfStart, kfEnd, kbStart, kbEnd := 0, 0, 0, 0
+	for D := 0; D <= maxD; D++ {
+		for k := -D + kfStart; k <= D-kfEnd; k += 2 {
+			var x int
+			if k == -D || (k != D && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.equal(d.src[srcStart+x], d.dst[dstStart+y]) {
+				x, y = x+1, y+1
+			}
+			forward[offset+k] = x
+			switch {
+			case x > n:
+				kfEnd += 2
+			case y > m:
+				kfStart += 2
+			case odd:
+				if kb := delta - k; kb >= -D && kb <= D && backward[offset+kb] != -1 && x >= n-backward[offset+kb] {
+					return srcStart + x, dstStart + y
+				}
+			}
+		}
+		for k := -D + kbStart; k <= D-kbEnd; k += 2 {
+			var x int
+			if k == -D || (k != D && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.equal(d.src[srcEnd-x-1], d.dst[dstEnd-y-1]) {
+				x, y = x+1, y+1
+			}
+			backward[offset+k] = x
+			switch {
+			case x > n:
+				kbEnd += 2
+			case y > m:
+				kbStart += 2
+			case !odd:
+				if kf := delta - k; kf >= -D && kf <= D && forward[offset+kf] != -1 && forward[offset+kf] >= n-x {
+					xf := forward[offset+kf]
+					return srcStart + xf, dstStart + xf - kf
+				}
+			}
+		}
+	}
+	// Paths always overlap within maxD. This is only for safety.
+	return srcEnd, dstStart
+}
+
+// normalize put deletions before insertions between equal hunks.
+func (d *differ[T]) normalize() (rets EditScript) {
+	for i := 0; i < len(d.script); {
+		if d.script[i].Kind == EditEqual {
+			rets = append(rets, d.script[i])
+			i++
+			continue
+		}
+		j := i
+		del := Hunk{Kind: EditDelete, SrcStart: d.script[i].SrcStart, DstStart: d.script[i].DstStart}
+		ins := Hunk{Kind: EditInsert}
+		for ; j < len(d.script) && d.script[j].Kind != EditEqual; j++ {
+			del.SrcEnd, ins.DstEnd = d.script[j].SrcEnd, d.script[j].DstEnd
+		}
+		del.DstEnd = del.DstStart
+		ins.SrcStart, ins.SrcEnd, ins.DstStart = del.SrcEnd, del.SrcEnd, del.DstStart
+		if del.SrcStart != del.SrcEnd {
+			rets = append(rets, del)
+		}
+		if ins.DstStart != ins.DstEnd {
+			rets = append(rets, ins)
+		}
+		i = j
+	}
+	return
+}
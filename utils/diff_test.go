@@ -0,0 +1,103 @@
+package utils_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+// apply turn src into dst by script, and check equal hunks.
+func apply(src, dst []byte, script utils.EditScript) ([]byte, bool) {
+	var ret []byte
+	srcIndex, dstIndex := 0, 0
+	for _, h := range script {
+		if h.SrcStart != srcIndex || h.DstStart != dstIndex {
+			return nil, false
+		}
+		switch h.Kind {
+		case utils.EditEqual:
+			if string(src[h.SrcStart:h.SrcEnd]) != string(dst[h.DstStart:h.DstEnd]) {
+				return nil, false
+			}
+			ret = append(ret, src[h.SrcStart:h.SrcEnd]...)
+		case utils.EditInsert:
+			ret = append(ret, dst[h.DstStart:h.DstEnd]...)
+		}
+		srcIndex, dstIndex = h.SrcEnd, h.DstEnd
+	}
+	return ret, srcIndex == len(src) && dstIndex == len(dst)
+}
+
+func TestDiff(t *testing.T) {
+	type Data struct {
+		src    string
+		dst    string
+		result []utils.Hunk
+	}
+	data := []Data{
+		Data{src: "abce", dst: "bcde", result: []utils.Hunk{
+			utils.Hunk{Kind: utils.EditDelete, SrcStart: 0, SrcEnd: 1, DstStart: 0, DstEnd: 0},
+			utils.Hunk{Kind: utils.EditEqual, SrcStart: 1, SrcEnd: 3, DstStart: 0, DstEnd: 2},
+			utils.Hunk{Kind: utils.EditInsert, SrcStart: 3, SrcEnd: 3, DstStart: 2, DstEnd: 3},
+			utils.Hunk{Kind: utils.EditEqual, SrcStart: 3, SrcEnd: 4, DstStart: 3, DstEnd: 4},
+		}},
+		Data{src: "", dst: "ab", result: []utils.Hunk{
+			utils.Hunk{Kind: utils.EditInsert, SrcStart: 0, SrcEnd: 0, DstStart: 0, DstEnd: 2},
+		}},
+		Data{src: "ab", dst: "ab", result: []utils.Hunk{
+			utils.Hunk{Kind: utils.EditEqual, SrcStart: 0, SrcEnd: 2, DstStart: 0, DstEnd: 2},
+		}},
+	}
+	for _, d := range data {
+		script := utils.Diff([]byte(d.src), []byte(d.dst))
+		if len(script) != len(d.result) {
+			t.Errorf("Get %v from %v, %v. Expect %v.\n", script, d.src, d.dst, d.result)
+			continue
+		}
+		for i := range script {
+			if script[i] != d.result[i] {
+				t.Errorf("Get %v from %v, %v. Expect %v.\n", script, d.src, d.dst, d.result)
+				break
+			}
+		}
+	}
+	// Random cases are checked against the distance given by `EditDistance`.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		src, dst := make([]byte, r.Intn(40)), make([]byte, r.Intn(40))
+		for j := range src {
+			src[j] = byte('a' + r.Intn(4))
+		}
+		for j := range dst {
+			dst[j] = byte('a' + r.Intn(4))
+		}
+		script := utils.Diff(src, dst)
+		if ret, ok := apply(src, dst, script); !ok || string(ret) != string(dst) {
+			t.Fatalf("Get invalid script %v from %s, %s.\n", script, src, dst)
+		}
+		if script.Distance() != utils.EditDistance(src, dst, -1) {
+			t.Fatalf("Get %v from %s, %s. Expect %v.\n", script.Distance(), src, dst, utils.EditDistance(src, dst, -1))
+		}
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	src := []string{"第一章 绯红", "第二章 情况", "第三章 笔记"}
+	dst := []string{"第一章绯红", "第二章情况（求收藏）", "第三章  笔记"}
+	script := utils.DiffFunc(src, dst, func(a, b string) bool {
+		return strings.Fields(a)[0][:9] == strings.Fields(b)[0][:9] // `第X章` takes 9 bytes
+	})
+	if len(script) != 1 || script[0].Kind != utils.EditEqual {
+		t.Errorf("Get %v. Expect one equal hunk.\n", script)
+	}
+}
+
+func BenchmarkDiffScript(b *testing.B) {
+	data := catalogues(2, 3000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		utils.Diff(*data[0], *data[1])
+	}
+}
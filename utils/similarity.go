@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+const (
+	minHashSize = 128
+	// minHashMargin is added to estimated Jaccard index, since MinHash only gives an estimation.
+	minHashMargin = 0.15
+)
+
+// HashStrings give 64-bit hashes of strings, so that comparing them is cheap.
+func HashStrings(s StringSlices) []uint64 {
+	rets := make([]uint64, len(s), len(s))
+	for i, str := range s {
+		h := fnv.New64a()
+		h.Write([]byte(str))
+		rets[i] = h.Sum64()
+	}
+	return rets
+}
+
+// mix is the finalizer of splitmix64, used to derive independent hashes from one.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// MinHash is the signature of a set, whose similarity to another estimates their Jaccard index.
+type MinHash []uint64
+
+// NewMinHash give the signature of set of hashes.
+func NewMinHash(hashes []uint64) MinHash {
+	rets := make(MinHash, minHashSize, minHashSize)
+	for i := range rets {
+		rets[i] = math.MaxUint64
+	}
+	for _, h := range hashes {
+		for i := range rets {
+			if v := mix(h ^ uint64(i+1)*0x9e3779b97f4a7c15); v < rets[i] {
+				rets[i] = v
+			}
+		}
+	}
+	return rets
+}
+
+// Jaccard estimate the Jaccard index of sets of m and n.
+func (m MinHash) Jaccard(n MinHash) float64 {
+	if len(m) == 0 || len(m) != len(n) {
+		return 0
+	}
+	same := 0
+	for i := range m {
+		if m[i] == n[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(m))
+}
+
+// EditDistance give the number of insertions and deletions turning src into dst, using the forward pass of
+// Myers Algorithm in O((N+M)·D) time and O(D) space. -1 is returned as soon as distance exceeds limit.
+// @param limit int negative means no limit.
+func EditDistance[T comparable](src, dst []T, limit int) int {
+	n, m := len(src), len(dst)
+	if limit < 0 || limit > n+m {
+		limit = n + m
+	}
+	if n-m > limit || m-n > limit {
+		return -1
+	}
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	for d := 0; d <= limit; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && src[x] == dst[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return d
+			}
+		}
+	}
+	return -1
+}
+
+// similarSlice prepare a string slice for `PartitionStringSlices`.
+type similarSlice struct {
+	hashes    []uint64
+	signature MinHash
+	distinct  int
+}
+
+func newSimilarSlice(s StringSlices) *similarSlice {
+	hashes := HashStrings(s)
+	set := make(map[uint64]bool, len(hashes))
+	for _, h := range hashes {
+		set[h] = true
+	}
+	return &similarSlice{hashes: hashes, signature: NewMinHash(hashes), distinct: len(set)}
+}
+
+// within tells whether edit distance between u and v is no more than limit.
+// Pairs far from each other are rejected by lengths or MinHash before the exact but bounded diff.
+func (u *similarSlice) within(v *similarSlice, limit int) bool {
+	n, m := len(u.hashes), len(v.hashes)
+	if n-m > limit || m-n > limit {
+		return false
+	}
+	// Common elements are at most |U∩V| = J(|U|+|V|)/(1+J), so distance is at least n+m-2|U∩V|.
+	if u.distinct == n && v.distinct == m { // Only sound for sets
+		j := u.signature.Jaccard(v.signature) + minHashMargin
+		if j < 1 && float64(n+m)*(1-j)/(1+j) > float64(limit) {
+			return false
+		}
+	}
+	return EditDistance(u.hashes, v.hashes, limit) >= 0
+}
@@ -1,6 +1,7 @@
 package utils_test
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/RaymondJiangkw/Lazy/utils"
@@ -81,3 +82,54 @@ func TestCalculateDiff(t *testing.T) {
 		}
 	}
 }
+
+// catalogues give n catalogues of length chapters, which are similar except for the last one.
+func catalogues(n int, length int) []*utils.StringSlices {
+	rets := make([]*utils.StringSlices, n, n)
+	for i := 0; i < n; i++ {
+		s := make(utils.StringSlices, 0, length)
+		for j := 0; j < length; j++ {
+			switch {
+			case i == n-1:
+				s = append(s, "其他小说第"+strconv.Itoa(j)+"章")
+			case j%100 == i: // Every catalogue lacks a few chapters.
+			default:
+				s = append(s, "第"+strconv.Itoa(j)+"章")
+			}
+		}
+		rets[i] = &s
+	}
+	return rets
+}
+
+func TestPartition(t *testing.T) {
+	data := catalogues(5, 300)
+	partitions, err := utils.PartitionStringSlices(data, 0.5)
+	if err != nil || len(partitions) != 2 || len(partitions[0]) != 4 || len(partitions[1]) != 1 {
+		t.Errorf("Get %v partitions. Expect 2 partitions of 4 and 1.\n", len(partitions))
+	}
+	partitions, _ = utils.PartitionStringSlices(data[:2], 6)
+	if len(partitions) != 1 {
+		t.Errorf("Get %v partitions. Expect 1.\n", len(partitions))
+	}
+	partitions, _ = utils.PartitionStringSlices(data[:2], 5)
+	if len(partitions) != 2 {
+		t.Errorf("Get %v partitions. Expect 2.\n", len(partitions))
+	}
+}
+
+func BenchmarkPartition(b *testing.B) {
+	data := catalogues(10, 3000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		utils.PartitionStringSlices(data, 0.5)
+	}
+}
+
+func BenchmarkDiff(b *testing.B) {
+	data := catalogues(2, 3000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		utils.DiffStringSlices(*data[0], *data[1])
+	}
+}
@@ -1,12 +1,14 @@
 package utils
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -75,7 +77,8 @@ func requestSetHeader(r *http.Request) {
 // fetch enable the optimization of TIMEOUT Setting, Redirect of `window.onlocation=`, and Cookie Check by double requesting.
 // @param url string input will be normalized.
 // @param redirect bool determine whether redirect the page, if `window.location=` exists.
-func fetch(url string, redirect bool, timeout time.Duration, useCookie bool) (*string, error) {
+// @param binary bool keep the body as it is, e.g. images, instead of decoding it as text.
+func fetch(url string, redirect bool, timeout time.Duration, useCookie bool, binary bool) (*string, error) {
 	url = NormalizeURL(url)
 	client := http.Client{
 		Timeout: timeout,
@@ -106,6 +109,14 @@ func fetch(url string, redirect bool, timeout time.Duration, useCookie bool) (*s
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf(statusErrorFormat, resp.StatusCode)
 	}
+	if binary {
+		raw, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		content := string(raw)
+		return &content, nil
+	}
 	content, err := DecodeString(resp.Body)
 	if err != nil {
 		return nil, err
@@ -116,7 +127,7 @@ func fetch(url string, redirect bool, timeout time.Duration, useCookie bool) (*s
 			if err != nil {
 				return &content, err
 			}
-			return fetch(newURL, redirect, timeout, useCookie)
+			return fetch(newURL, redirect, timeout, useCookie, binary)
 		}
 	}
 	return &content, nil
@@ -133,13 +144,13 @@ func initFetch() {
 }
 
 // @param url string e.g. http://www.google.com www.google.com
-func fetchWithTry(url string, redirect bool, errWriter io.Writer, timeout time.Duration, useCookie bool) (data *string, err error) {
+func fetchWithTry(url string, redirect bool, errWriter io.Writer, timeout time.Duration, useCookie bool, binary bool) (data *string, err error) {
 	for i := 0; i < fetchMaximumTry; i++ {
 		if i != 0 {
 			fmt.Fprintf(errWriter, "Encounter Error %v while fetching url %s. Retry the %d th time. Pause %d secs.\n", err, url, i, pauseSeconds)
 			time.Sleep(time.Second * pauseSeconds)
 		}
-		data, err = fetch(url, redirect, timeout, useCookie)
+		data, err = fetch(url, redirect, timeout, useCookie, binary)
 		if err == nil {
 			break
 		}
@@ -171,14 +182,14 @@ func fetchReadCache(url string) (*string, error) {
 }
 
 // fetchOneURL guarantee returns despite the possibility of broken situation of cache mechanism.
-func fetchOneURL(url string, refresh bool, redirect bool, errWriter io.Writer, timeout time.Duration, useCookie bool) (str_ptr *string, err error, c <-chan struct{}) {
+func fetchOneURL(url string, refresh bool, redirect bool, errWriter io.Writer, timeout time.Duration, useCookie bool, binary bool) (str_ptr *string, err error, c <-chan struct{}) {
 	if !refresh {
 		str_ptr, err = fetchReadCache(url)
 		if err == nil {
 			return
 		}
 	}
-	str_ptr, err = fetchWithTry(url, redirect, errWriter, timeout, useCookie)
+	str_ptr, err = fetchWithTry(url, redirect, errWriter, timeout, useCookie, binary)
 	if err != nil {
 		return nil, err, nil
 	}
@@ -205,7 +216,7 @@ func syncFetch(options *FetchOption, urls []string) (data []*string, errs []erro
 				wg.Done()
 			}()
 			fetchTokens <- struct{}{}
-			_data, _err, _ch := fetchOneURL(url, options.Refresh, options.Redirect, options.ErrWriter, options.Timeout, options.UseCookie)
+			_data, _err, _ch := fetchOneURL(url, options.Refresh, options.Redirect, options.ErrWriter, options.Timeout, options.UseCookie, options.Binary)
 			data[i], errs[i], IOCompletes[i] = _data, _err, _ch
 			<-fetchTokens
 		}(i, url)
@@ -229,7 +240,7 @@ func asyncFetch(options *FetchOption, receiver chan<- FetchResult, urls []string
 		go func(i int, url string) {
 			defer wg.Done()
 			fetchTokens <- struct{}{}
-			_data, _err, _ch := fetchOneURL(url, options.Refresh, options.Redirect, options.ErrWriter, options.Timeout, options.UseCookie)
+			_data, _err, _ch := fetchOneURL(url, options.Refresh, options.Redirect, options.ErrWriter, options.Timeout, options.UseCookie, options.Binary)
 			IOCompletes[i] = _ch
 			receiver <- FetchResult{data: _data, err: _err, url: url}
 			<-fetchTokens
@@ -253,6 +264,8 @@ type FetchOption struct {
 	Refresh   bool
 	Redirect  bool
 	UseCookie bool
+	// Binary keeps bodies as they are, e.g. images, instead of decoding them as text. Redirect is ignored then.
+	Binary    bool
 	ErrWriter io.Writer
 	// signal will be sent whenever a url is processed, either successful or unsuccessful.
 	Signal   chan<- struct{}
@@ -267,6 +280,7 @@ type FetchOption struct {
  * @param options.Timeout time.Duration (default: {@link defaultTimeout})
  * @param options.Refresh bool
  * @param options.Redirect bool
+ * @param options.Binary bool whether to keep bodies undecoded, e.g. images.
  * @param options.ErrWriter io.Writer (default: ioutil.Discard)
  * @param options.Signal chan<-struct{} signal will be sent whenever a url is processed, either successful or unsuccessful.
  * @param options.Receiver chan<-FetchResult, FetchResult:{data *string, err error, url string}.
@@ -289,6 +303,108 @@ func Fetch(urls []string, options *FetchOption) ([]*string, []error, []<-chan st
 	}
 }
 
+// `Redirect` of search engines
+
+// redirectors are hosts and paths wrapping target URLs of search results.
+var redirectors = []struct {
+	host string
+	path string
+}{
+	{"baidu.com", "/link"},
+	{"sogou.com", "/link"},
+	{"bing.com", "/ck/a"},
+	{"duckduckgo.com", "/l/"},
+}
+
+// redirectPatterns match targets in pages redirecting by JavaScript, e.g. `location.href = "..."`,
+// or <meta http-equiv="refresh">, whose url may be unquoted, e.g. `content="0; URL=http://..."`.
+// NOTICE: Attributes like `data-url` and `data-location` are not redirections.
+var redirectPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(?:^|[^\w-])location(?:\.href)?\s*=\s*["']([^"']+)["']`),
+	regexp.MustCompile(`(?i)(?:^|[^\w-])location\.replace\(\s*["']([^"']+)["']`),
+	regexp.MustCompile(`(?i)<meta\b[^>]*\bcontent\s*=\s*["']?\s*\d+\s*;\s*url\s*=\s*["']?([^"'\s>]+)`),
+}
+
+func isRedirector(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, r := range redirectors {
+		if (host == r.host || strings.HasSuffix(host, "."+r.host)) && strings.HasPrefix(u.Path, r.path) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRedirectURL tells whether URL is a redirector of search engines, e.g. `https://www.baidu.com/link?url=...`.
+func IsRedirectURL(URL string) bool {
+	u, err := url.Parse(NormalizeURL(URL))
+	return err == nil && isRedirector(u)
+}
+
+// decodeRedirectURL decode target carried in parameters of redirector. Empty string will be returned if fails.
+func decodeRedirectURL(u *url.URL) string {
+	q := u.Query()
+	// DuckDuckGo: /l/?uddg=<escaped url>
+	if target := q.Get("uddg"); target != "" {
+		return target
+	}
+	// Bing: /ck/a?...&u=a1<base64 url>
+	if target := q.Get("u"); strings.HasPrefix(target, "a1") {
+		if b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(target[2:], "=")); err == nil {
+			return string(b)
+		}
+	}
+	return ""
+}
+
+// ResolveURL give the real target of URL wrapped by search engines. Other URLs are returned as they are.
+// Target is decoded from parameters if possible. Otherwise, redirector is requested without following redirection,
+// and target is read from `Location` header, or JavaScript/<meta> redirection in page.
+func ResolveURL(URL string, timeout time.Duration) (string, error) {
+	u, err := url.Parse(NormalizeURL(URL))
+	if err != nil {
+		return "", err
+	}
+	if !isRedirector(u) {
+		return URL, nil
+	}
+	if target := decodeRedirectURL(u); target != "" {
+		return target, nil
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	client := http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	requestSetHeader(req)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if location := resp.Header.Get("Location"); location != "" {
+		return CompleteURL(u.String(), location)
+	}
+	content, err := DecodeString(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	for _, pattern := range redirectPatterns {
+		if m := pattern.FindStringSubmatch(content); m != nil {
+			return CompleteURL(u.String(), m[1])
+		}
+	}
+	return "", Invalid
+}
+
 // `Extract`
 
 const (
@@ -390,6 +506,11 @@ func (c Candidates) indexOf(str string) int {
 	return -1
 }
 
+// Contains tells whether str is in c.
+func (c Candidates) Contains(str string) bool {
+	return c.indexOf(str) != -1
+}
+
 func IsElementNode() NodeFunc {
 	return NodeFunc(func(node *html.Node) bool {
 		return node.Type == html.ElementNode
@@ -477,6 +598,29 @@ func ParseATags(n_s []*html.Node) (rets []TagA) {
 	return
 }
 
+// ParseMetaTags collect `content` of <meta> tags, keyed by lowercased `property` or `name`.
+// The first one wins when a key appears multiple times.
+func ParseMetaTags(root *html.Node) map[string]string {
+	rets := make(map[string]string)
+	for _, n := range Select(root, "meta") {
+		var key, content string
+		for _, attr := range n.Attr {
+			switch strings.ToLower(attr.Key) {
+			case "property", "name":
+				if key == "" {
+					key = strings.ToLower(strings.TrimSpace(attr.Val))
+				}
+			case "content":
+				content = strings.TrimSpace(attr.Val)
+			}
+		}
+		if _, ok := rets[key]; key != "" && !ok {
+			rets[key] = content
+		}
+	}
+	return rets
+}
+
 func SignatureURL(URL string) (string, error) {
 	URL = NormalizeURL(URL)
 	b, e := url.Parse(URL)
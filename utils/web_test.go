@@ -0,0 +1,90 @@
+package utils_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+func TestIsRedirectURL(t *testing.T) {
+	type Data struct {
+		url    string
+		expect bool
+	}
+	data := []Data{
+		Data{"https://www.baidu.com/link?url=abc", true},
+		Data{"www.sogou.com/link?url=abc", true},
+		Data{"https://cn.bing.com/ck/a?u=a1aHR0cDovL2EuY29tLw", true},
+		Data{"https://duckduckgo.com/l/?uddg=http%3A%2F%2Fa.com%2F", true},
+		Data{"https://www.baidu.com/s?wd=abc", false},
+		Data{"https://notbaidu.com/link?url=abc", false},
+		Data{"https://www.a.com/book/1/", false},
+	}
+	for _, d := range data {
+		if ret := utils.IsRedirectURL(d.url); ret != d.expect {
+			t.Errorf("Get %v from %v. Expect %v.\n", ret, d.url, d.expect)
+		}
+	}
+}
+
+// throughProxy send requests of `http.DefaultTransport` to handler, so that redirectors of search engines can be served locally.
+func throughProxy(t *testing.T, handler http.HandlerFunc) {
+	server := httptest.NewServer(handler)
+	proxy, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := http.DefaultTransport
+	http.DefaultTransport = &http.Transport{Proxy: http.ProxyURL(proxy)}
+	t.Cleanup(func() {
+		http.DefaultTransport = transport
+		server.Close()
+	})
+}
+
+func TestResolveURL(t *testing.T) {
+	throughProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("url") {
+		case "location":
+			http.Redirect(w, r, "http://a.com/book/1/", http.StatusFound)
+		case "meta":
+			fmt.Fprint(w, `<html><head><meta http-equiv="refresh" content="0; URL=http://a.com/book/2/"></head></html>`+padding)
+		case "quoted":
+			fmt.Fprint(w, `<html><head><meta http-equiv="refresh" content="0;url='/book/3/'"></head></html>`+padding)
+		case "script":
+			fmt.Fprint(w, `<html><body><div data-url="http://ads.com/" data-location="http://ads.com/"></div><script>window.location.replace("http://a.com/book/4/")</script></body></html>`+padding)
+		case "href":
+			fmt.Fprint(w, `<html><body><script>location.href = 'http://a.com/book/5/';</script></body></html>`+padding)
+		default:
+			fmt.Fprint(w, `<html><body><a data-url="http://ads.com/">ads</a></body></html>`+padding)
+		}
+	})
+	type Data struct {
+		url    string
+		expect string
+	}
+	data := []Data{
+		// Targets carried in parameters are decoded without requests.
+		Data{"https://duckduckgo.com/l/?uddg=http%3A%2F%2Fa.com%2Fbook%2F0%2F", "http://a.com/book/0/"},
+		Data{"https://cn.bing.com/ck/a?p=1&u=a1aHR0cDovL2EuY29tL2Jvb2svMC8", "http://a.com/book/0/"},
+		Data{"http://www.baidu.com/link?url=location", "http://a.com/book/1/"},
+		Data{"http://www.baidu.com/link?url=meta", "http://a.com/book/2/"},
+		Data{"http://www.baidu.com/link?url=quoted", "http://www.baidu.com/book/3/"},
+		Data{"http://www.sogou.com/link?url=script", "http://a.com/book/4/"},
+		Data{"http://www.sogou.com/link?url=href", "http://a.com/book/5/"},
+		// Other URLs are kept.
+		Data{"https://www.a.com/book/6/", "https://www.a.com/book/6/"},
+	}
+	for _, d := range data {
+		if ret, err := utils.ResolveURL(d.url, 0); err != nil || ret != d.expect {
+			t.Errorf("Get %v, %v from %v. Expect %v.\n", ret, err, d.url, d.expect)
+		}
+	}
+	if ret, err := utils.ResolveURL("http://www.baidu.com/link?url=none", 0); err == nil {
+		t.Errorf("Get %v from page without redirection. Expect error.\n", ret)
+	}
+}
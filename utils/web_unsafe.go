@@ -3,21 +3,35 @@
 package utils
 
 import (
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"golang.org/x/net/html"
+	"golang.org/x/text/encoding"
 )
 
-func search(host string, queryKey string, key string, pageKey string, itemsPerPage int, items int, pageValid func(*string) bool, selector string) (rets []TagA, err error) {
+// SearchProvider search key on some website and return links of results.
+// It should return Shortage together with results gotten, when it cannot offer enough items.
+type SearchProvider interface {
+	Name() string
+	Search(key string, items int) ([]TagA, error)
+}
+
+const (
+	maximumInvalidPages = 3 // anti-scraper pages tolerated before giving up a search
+)
+
+// @param page func(int) int convert the i th(0-based) page into value of pageKey.
+func search(host string, queryKey string, key string, pageKey string, page func(int) int, items int, pageValid func(*string) bool, selector string) (rets []TagA, err error) {
 	var getFromOnePage = func(page int) ([]TagA, error) {
 		URL, _ := AddQueryToURL(host, []string{queryKey, pageKey}, []string{key, strconv.Itoa(page)})
 		bodies, errs, ioCompletes := []*string(nil), []error(nil), []<-chan struct{}(nil)
 		defer func() {
 			WaitSync(ioCompletes)
 		}()
-		for {
+		for try := 0; ; try++ {
 			var chs []<-chan struct{}
 			bodies, errs, chs = Fetch([]string{URL}, &FetchOption{Refresh: true, UseCookie: true})
 			ioCompletes = append(ioCompletes, chs...)
@@ -27,6 +41,10 @@ func search(host string, queryKey string, key string, pageKey string, itemsPerPa
 			if pageValid(bodies[0]) {
 				break
 			}
+			// NOTICE: Give up anti-scraper pages at last, so that `ChainProvider` turns to the next provider.
+			if try+1 >= maximumInvalidPages {
+				return nil, Invalid
+			}
 			time.Sleep(defaultSleepTime)
 		}
 		doc, err := html.Parse(strings.NewReader(*bodies[0]))
@@ -34,11 +52,11 @@ func search(host string, queryKey string, key string, pageKey string, itemsPerPa
 			return nil, err
 		}
 		nodes := Select(doc, selector)
-		return ParseATags(nodes), nil
+		return completeTagA(host, ParseATags(nodes)), nil
 	}
 	i := 0
 	for len(rets) < items {
-		items, e := getFromOnePage(i * itemsPerPage)
+		items, e := getFromOnePage(page(i))
 		if e != nil {
 			return rets, e
 		}
@@ -51,52 +69,219 @@ func search(host string, queryKey string, key string, pageKey string, itemsPerPa
 	return
 }
 
-// searchBaidu search result from https://www.baidu.com.
+// completeTagA resolve relative links in results against host.
+func completeTagA(host string, tags []TagA) []TagA {
+	for i := range tags {
+		if href, err := CompleteURL(host, tags[i].Href); err == nil {
+			tags[i].Href = href
+		}
+	}
+	return tags
+}
+
+// EngineProvider search on a web search engine, which pages results with a query parameter.
+type EngineProvider struct {
+	Title        string
+	Host         string
+	QueryKey     string
+	PageKey      string
+	ItemsPerPage int
+	// PageByIndex tells whether PageKey accepts 1-based page index instead of offset of items.
+	PageByIndex bool
+	// PageValid tells whether a page is a real result page instead of an anti-scraper one.
+	PageValid func(*string) bool
+	Selector  string
+}
+
+func (p *EngineProvider) Name() string {
+	return p.Title
+}
+
+func (p *EngineProvider) Search(key string, items int) ([]TagA, error) {
+	var page = func(i int) int {
+		if p.PageByIndex {
+			return i + 1
+		}
+		return i * p.ItemsPerPage
+	}
+	var pageValid = p.PageValid
+	if pageValid == nil {
+		pageValid = func(*string) bool { return true }
+	}
+	return search(p.Host, p.QueryKey, key, p.PageKey, page, items, pageValid, p.Selector)
+}
+
+// SiteSearchProvider submit key to the search form of a single website, e.g. a novel site.
+// Only the first page of results is used.
+type SiteSearchProvider struct {
+	Title    string
+	Action   string // URL of search form
+	QueryKey string
+	// Params are extra fixed fields of search form.
+	Params map[string]string
+	// Encoding is used to encode key, since many Chinese sites expect GBK. nil means UTF-8.
+	Encoding encoding.Encoding
+	Selector string
+}
+
+func (p *SiteSearchProvider) Name() string {
+	return p.Title
+}
+
+// URL give the address of search results of key, where key is encoded by Encoding and Params are in sorted order.
+func (p *SiteSearchProvider) URL(key string) (string, error) {
+	if p.Encoding != nil {
+		var err error
+		if key, err = p.Encoding.NewEncoder().String(key); err != nil {
+			return "", err
+		}
+	}
+	names := make([]string, 0, len(p.Params))
+	for k := range p.Params {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	keys, values := []string{p.QueryKey}, []string{key}
+	for _, k := range names {
+		keys, values = append(keys, k), append(values, p.Params[k])
+	}
+	return AddQueryToURL(p.Action, keys, values)
+}
+
+func (p *SiteSearchProvider) Search(key string, items int) (rets []TagA, err error) {
+	URL, err := p.URL(key)
+	if err != nil {
+		return nil, err
+	}
+	bodies, errs, ioCompletes := Fetch([]string{URL}, &FetchOption{Refresh: true, Redirect: true, UseCookie: true})
+	defer WaitSync(ioCompletes)
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+	doc, err := html.Parse(strings.NewReader(*bodies[0]))
+	if err != nil {
+		return nil, err
+	}
+	rets = completeTagA(p.Action, ParseATags(Select(doc, p.Selector)))
+	if len(rets) < items {
+		return rets, Shortage
+	}
+	return rets[:items], nil
+}
+
+// ChainProvider try providers in order. Whenever one fails or returns Shortage, the next one is used to make up.
+// If none gives any result, the last error other than Shortage is returned, e.g. of network.
+type ChainProvider []SearchProvider
+
+func (c ChainProvider) Name() string {
+	names := make([]string, len(c), len(c))
+	for i, p := range c {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, ">")
+}
+
+func (c ChainProvider) Search(key string, items int) (rets []TagA, err error) {
+	exists := make(map[string]bool)
+	var lastErr error
+	for _, p := range c {
+		tags, e := p.Search(key, items-len(rets))
+		if e != nil && e != Shortage {
+			lastErr = e
+		}
+		for _, tag := range tags {
+			if !exists[tag.Href] {
+				exists[tag.Href] = true
+				rets = append(rets, tag)
+			}
+		}
+		if len(rets) >= items {
+			return rets, nil
+		}
+	}
+	if len(rets) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return rets, Shortage
+}
+
+// BaiduProvider search result from https://www.baidu.com.
 // However, there is an issue that Baidu has strict scraper test, which makes this function cost too much time.
-func searchBaidu(key string, items int) ([]TagA, error) {
-	const (
-		baiduHost         = "https://www.baidu.com/s"
-		baiduKey          = "wd"
-		baiduPage         = "pn"
-		baiduItemsPerPage = 10
-	)
-	var pageValid = func(content *string) bool {
+var BaiduProvider SearchProvider = &EngineProvider{
+	Title:        "baidu",
+	Host:         "https://www.baidu.com/s",
+	QueryKey:     "wd",
+	PageKey:      "pn",
+	ItemsPerPage: 10,
+	PageValid: func(content *string) bool {
 		return strings.Index(*content, "网络不给力，请稍后重试") == -1
-	}
-	return search(baiduHost, baiduKey, key, baiduPage, baiduItemsPerPage, items, pageValid, ".t > a")
+	},
+	Selector: ".t > a",
 }
 
-func searchBing(key string, items int) (rets []TagA, err error) {
-	const (
-		bingHost         = "https://cn.bing.com/search"
-		bingKey          = "q"
-		bingPage         = "first"
-		bingItemsPerPage = 10
-	)
-	var pageValid = func(content *string) bool {
+var BingProvider SearchProvider = &EngineProvider{
+	Title:        "bing",
+	Host:         "https://cn.bing.com/search",
+	QueryKey:     "q",
+	PageKey:      "first",
+	ItemsPerPage: 10,
+	PageValid: func(content *string) bool {
 		return strings.Index(*content, "没有与此相关的结果") == -1
-	}
-	return search(bingHost, bingKey, key, bingPage, bingItemsPerPage, items, pageValid, "h2 > a")
+	},
+	Selector: "h2 > a",
 }
 
+// DuckDuckGoProvider search result from the HTML-only version of https://duckduckgo.com.
+var DuckDuckGoProvider SearchProvider = &EngineProvider{
+	Title:        "duckduckgo",
+	Host:         "https://html.duckduckgo.com/html/",
+	QueryKey:     "q",
+	PageKey:      "s",
+	ItemsPerPage: 30,
+	Selector:     "a.result__a",
+}
+
+var SogouProvider SearchProvider = &EngineProvider{
+	Title:        "sogou",
+	Host:         "https://www.sogou.com/web",
+	QueryKey:     "query",
+	PageKey:      "page",
+	ItemsPerPage: 10,
+	PageByIndex:  true,
+	PageValid: func(content *string) bool {
+		return strings.Index(*content, "antispider") == -1
+	},
+	Selector: "h3 > a",
+}
+
+var searchProviders = map[string]SearchProvider{
+	"baidu":      BaiduProvider,
+	"bing":       BingProvider,
+	"duckduckgo": DuckDuckGoProvider,
+	"sogou":      SogouProvider,
+}
+
+// defaultSearchChain is used when neither Host nor Providers is given.
+var defaultSearchChain = ChainProvider{BingProvider, DuckDuckGoProvider, SogouProvider}
+
 type SearchOption struct {
 	Key string
-	// accept: baidu, bing. (default: bing)
-	Host  string
-	Items int // (default:10)
+	// accept: baidu, bing, duckduckgo, sogou. (default: bing, then duckduckgo and sogou when shortage)
+	Host string
+	// Providers are chained in order and take precedence over Host.
+	Providers []SearchProvider
+	Items     int // (default:10)
 }
 
 func Search(options *SearchOption) ([]TagA, error) {
 	if options.Items <= 0 {
 		options.Items = defaultItems
 	}
-	if options.Host == "" {
-		options.Host = "bing"
+	if len(options.Providers) > 0 {
+		return ChainProvider(options.Providers).Search(options.Key, options.Items)
 	}
-	switch options.Host {
-	case "baidu":
-		return searchBaidu(options.Key, options.Items)
-	default:
-		return searchBing(options.Key, options.Items)
+	if p, ok := searchProviders[options.Host]; ok {
+		return p.Search(options.Key, options.Items)
 	}
+	return defaultSearchChain.Search(options.Key, options.Items)
 }
@@ -0,0 +1,81 @@
+package utils_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/RaymondJiangkw/Lazy/utils"
+)
+
+// inTempDir run tests in a temporary directory, so that cache of `Fetch` is not left in the tree.
+func inTempDir(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// padding make pages longer than 1024 bytes, which `DecodeString` peeks to determine encoding.
+var padding = "<!--" + strings.Repeat(" ", 1024) + "-->"
+
+func TestChainProvider(t *testing.T) {
+	inTempDir(t)
+	var blocked int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/blocked":
+			blocked++
+			fmt.Fprint(w, `<html><body>antispider</body></html>`+padding)
+		case "/engine":
+			fmt.Fprintf(w, `<html><body><h3><a href="/book/%s/1">A</a></h3><h3><a href="/book/%s/2">B</a></h3></body></html>%s`, r.URL.Query().Get("page"), r.URL.Query().Get("page"), padding)
+		}
+	}))
+	defer server.Close()
+	var pageValid = func(content *string) bool { return !strings.Contains(*content, "antispider") }
+	blockedProvider := &utils.EngineProvider{Title: "blocked", Host: server.URL + "/blocked", QueryKey: "q", PageKey: "page", ItemsPerPage: 2, PageByIndex: true, PageValid: pageValid, Selector: "h3 > a"}
+	engineProvider := &utils.EngineProvider{Title: "engine", Host: server.URL + "/engine", QueryKey: "q", PageKey: "page", ItemsPerPage: 2, PageByIndex: true, PageValid: pageValid, Selector: "h3 > a"}
+
+	if _, err := blockedProvider.Search("key", 2); err == nil {
+		t.Errorf("Get %v from %v. Expect error.\n", err, blockedProvider.Name())
+	}
+	// Errors other than Shortage are kept when nothing is found.
+	if rets, err := (utils.ChainProvider{blockedProvider}).Search("key", 2); err == nil || err == utils.Shortage {
+		t.Errorf("Get %v, %v from chain of %v. Expect its error.\n", rets, err, blockedProvider.Name())
+	}
+	blocked = 0
+	chain := utils.ChainProvider{blockedProvider, engineProvider}
+	rets, err := chain.Search("key", 3)
+	if err != nil {
+		t.Fatalf("Get %v from %v. Expect %v.\n", err, chain.Name(), nil)
+	}
+	expects := []string{server.URL + "/book/1/1", server.URL + "/book/1/2", server.URL + "/book/2/1"}
+	if len(rets) < len(expects) {
+		t.Fatalf("Get %v from %v. Expect %v.\n", rets, chain.Name(), expects)
+	}
+	for i, expect := range expects {
+		if rets[i].Href != expect {
+			t.Errorf("Get %v from %v. Expect %v.\n", rets[i].Href, chain.Name(), expect)
+		}
+	}
+	if blocked == 0 {
+		t.Errorf("Get %v requests to %v. Expect some.\n", blocked, blockedProvider.Name())
+	}
+}
+
+func TestSiteSearchProviderURL(t *testing.T) {
+	p := &utils.SiteSearchProvider{Action: "https://example.com/search.php", QueryKey: "q", Params: map[string]string{"type": "all", "a": "1", "s": "x"}}
+	expect := "https://example.com/search.php?a=1&q=%E8%AF%A1%E7%A7%98&s=x&type=all"
+	for i := 0; i < 10; i++ {
+		if URL, err := p.URL("诡秘"); err != nil || URL != expect {
+			t.Fatalf("Get %v, %v. Expect %v.\n", URL, err, expect)
+		}
+	}
+}